@@ -1,11 +1,13 @@
 package braintree
 
+import "context"
+
 type AddOnGateway struct {
 	*Braintree
 }
 
-func (g *AddOnGateway) All() ([]AddOn, error) {
-	resp, err := g.execute("GET", "add_ons", nil)
+func (g *AddOnGateway) All(ctx context.Context) ([]AddOn, error) {
+	resp, err := g.execute(ctx, "AddOn", "GET", "add_ons", nil)
 	if err != nil {
 		return nil, err
 	}
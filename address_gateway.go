@@ -1,6 +1,7 @@
 package braintree
 
 import (
+	"context"
 	"encoding/xml"
 )
 
@@ -9,13 +10,13 @@ type AddressGateway struct {
 }
 
 // Create creates a new address for the specified customer id.
-func (g *AddressGateway) Create(a *Address) (*Address, error) {
+func (g *AddressGateway) Create(ctx context.Context, a *Address) (*Address, error) {
 	// Copy address so that field sanitation won't affect original
 	var cp Address = *a
 	cp.CustomerId = ""
 	cp.XMLName = xml.Name{Local: "address"}
 
-	resp, err := g.execute("POST", "customers/"+a.CustomerId+"/addresses", &cp)
+	resp, err := g.execute(ctx, "Address", "POST", "customers/"+a.CustomerId+"/addresses", &cp)
 	if err != nil {
 		return nil, err
 	}
@@ -27,8 +28,8 @@ func (g *AddressGateway) Create(a *Address) (*Address, error) {
 }
 
 // Delete deletes the address for the specified id and customer id.
-func (g *AddressGateway) Delete(customerId, addrId string) error {
-	resp, err := g.execute("DELETE", "customers/"+customerId+"/addresses/"+addrId, nil)
+func (g *AddressGateway) Delete(ctx context.Context, customerId, addrId string) error {
+	resp, err := g.execute(ctx, "Address", "DELETE", "customers/"+customerId+"/addresses/"+addrId, nil)
 	if err != nil {
 		return err
 	}
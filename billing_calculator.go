@@ -0,0 +1,329 @@
+package braintree
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// BillingCycle is a projected charge of a subscription.
+type BillingCycle struct {
+	// Number counts billing cycles from 1, like Subscription.CurrentBillingCycle.
+	Number int
+	// BillingDate is the day the cycle is charged, which is the first day of its period.
+	BillingDate time.Time
+	// PeriodEndDate is the last day of the cycle's billing period.
+	PeriodEndDate time.Time
+	// Amount is the price plus add-ons minus discounts, but never less than zero.
+	Amount *Decimal
+}
+
+// BillingState is the position of a subscription in its billing schedule.
+type BillingState struct {
+	// CurrentBillingCycle is the number of cycles already charged, 0 before the first.
+	CurrentBillingCycle int
+	// NextBillingDate is the day the next cycle is charged.
+	NextBillingDate time.Time
+	// BillingDayOfMonth is the day of the month charges are made. Days past the end
+	// of a month, such as 31, fall on its last day. If zero, the calculator's
+	// BillingDayOfMonth or else the day of NextBillingDate is used.
+	BillingDayOfMonth int
+}
+
+// NewBillingState returns the billing state of an existing subscription.
+func NewBillingState(sub *Subscription) (BillingState, error) {
+	var state BillingState
+	if sub.NextBillingDate == nil || sub.NextBillingDate.IsZero() {
+		return state, errors.New("subscription has no next billing date")
+	}
+	state.NextBillingDate = sub.NextBillingDate.Time
+	if sub.CurrentBillingCycle != "" {
+		n, err := strconv.Atoi(sub.CurrentBillingCycle)
+		if err != nil {
+			return state, fmt.Errorf("invalid current billing cycle %q", sub.CurrentBillingCycle)
+		}
+		state.CurrentBillingCycle = n
+	}
+	if sub.BillingDayOfMonth != "" {
+		day, err := strconv.Atoi(sub.BillingDayOfMonth)
+		if err != nil {
+			return state, fmt.Errorf("invalid billing day of month %q", sub.BillingDayOfMonth)
+		}
+		state.BillingDayOfMonth = day
+	}
+	return state, nil
+}
+
+// BillingCalculator projects the billing schedule of a subscription and the charges
+// of changing its price, following Braintree's rules without calling the gateway:
+//
+//   - Without a trial or billing day of month, the first charge is on the start date.
+//   - A trial moves the first charge to the day the trial ends.
+//   - With a billing day of month, the first charge is on the first billing day on or
+//     after the start date, or the end of the trial.
+//   - Subscriptions first charged on the 29th, 30th or 31st are charged on the last
+//     day of every month.
+//   - Price changes within a billing period are prorated by the days left in the
+//     period, including the day of the change.
+//
+// Dates are handled as calendar days, ignoring the time of day.
+type BillingCalculator struct {
+	// BillingFrequency is the number of months between charges.
+	BillingFrequency int
+	// BillingDayOfMonth is the day of the month charges are made, or 0 to charge on
+	// the day of the month the subscription starts.
+	BillingDayOfMonth int
+	// NumberOfBillingCycles is the number of cycles charged, or 0 if the subscription
+	// never expires.
+	NumberOfBillingCycles int
+	Price                 *Decimal
+	// TrialDuration is the length of the trial in TrialDurationUnit, or 0 for no trial.
+	TrialDuration     int
+	TrialDurationUnit string
+	// AddOns and Discounts apply to each cycle until they expire. Their
+	// CurrentBillingCycle is the number of cycles they have already been charged.
+	AddOns    []Modification
+	Discounts []Modification
+}
+
+// NewBillingCalculator returns a calculator for subscriptions to plan.
+func NewBillingCalculator(plan *Plan) *BillingCalculator {
+	c := &BillingCalculator{Price: plan.Price}
+	if plan.BillingFrequency != nil && plan.BillingFrequency.Valid {
+		c.BillingFrequency = int(plan.BillingFrequency.Int64)
+	}
+	if plan.BillingDayOfMonth != nil && plan.BillingDayOfMonth.Valid {
+		c.BillingDayOfMonth = int(plan.BillingDayOfMonth.Int64)
+	}
+	if plan.NumberOfBillingCycles != nil && plan.NumberOfBillingCycles.Valid {
+		c.NumberOfBillingCycles = int(plan.NumberOfBillingCycles.Int64)
+	}
+	if plan.TrialPeriod != nil && plan.TrialPeriod.Valid && plan.TrialPeriod.Bool && plan.TrialDuration != nil && plan.TrialDuration.Valid {
+		c.TrialDuration = int(plan.TrialDuration.Int64)
+		c.TrialDurationUnit = plan.TrialDurationUnit
+	}
+	for _, a := range plan.AddOns.AddOns {
+		c.AddOns = append(c.AddOns, a.Modification)
+	}
+	for _, d := range plan.Discounts.Discounts {
+		c.Discounts = append(c.Discounts, d.Modification)
+	}
+	return c
+}
+
+// ApplySubscription overrides the plan's price, number of billing cycles, add-ons and
+// discounts with those of the subscription.
+func (c *BillingCalculator) ApplySubscription(sub *Subscription) {
+	if sub.Price != nil {
+		c.Price = sub.Price
+	}
+	if sub.NeverExpires != nil && sub.NeverExpires.Valid && sub.NeverExpires.Bool {
+		c.NumberOfBillingCycles = 0
+	} else if sub.NumberOfBillingCycles != nil && sub.NumberOfBillingCycles.Valid {
+		c.NumberOfBillingCycles = int(sub.NumberOfBillingCycles.Int64)
+	}
+	c.AddOns, c.Discounts = nil, nil
+	for _, a := range sub.AddOns.AddOns {
+		c.AddOns = append(c.AddOns, a.Modification)
+	}
+	for _, d := range sub.Discounts.Discounts {
+		c.Discounts = append(c.Discounts, d.Modification)
+	}
+}
+
+func (c *BillingCalculator) validate() error {
+	if c.BillingFrequency < 1 {
+		return fmt.Errorf("invalid billing frequency %d", c.BillingFrequency)
+	}
+	if c.Price == nil {
+		return errors.New("price is required")
+	}
+	if c.BillingDayOfMonth < 0 || c.BillingDayOfMonth > 31 {
+		return fmt.Errorf("invalid billing day of month %d", c.BillingDayOfMonth)
+	}
+	return nil
+}
+
+// Start returns the billing state of a subscription started on start.
+func (c *BillingCalculator) Start(start time.Time) (BillingState, error) {
+	if err := c.validate(); err != nil {
+		return BillingState{}, err
+	}
+	start = calendarDay(start)
+	state := BillingState{BillingDayOfMonth: c.BillingDayOfMonth}
+	first := start
+	if c.TrialDuration > 0 {
+		switch c.TrialDurationUnit {
+		case SubscriptionTrialDurationUnitDay:
+			first = start.AddDate(0, 0, c.TrialDuration)
+		case SubscriptionTrialDurationUnitMonth:
+			first = addMonths(start, c.TrialDuration, start.Day())
+		default:
+			return BillingState{}, fmt.Errorf("invalid trial duration unit %q", c.TrialDurationUnit)
+		}
+	}
+	if c.BillingDayOfMonth > 0 {
+		next := addMonths(first, 0, c.BillingDayOfMonth)
+		if next.Before(first) {
+			next = addMonths(first, 1, c.BillingDayOfMonth)
+		}
+		first = next
+	}
+	state.NextBillingDate = first
+	if state.BillingDayOfMonth == 0 {
+		state.BillingDayOfMonth = state.NextBillingDate.Day()
+	}
+	if state.BillingDayOfMonth > 28 {
+		state.BillingDayOfMonth = 31
+	}
+	return state, nil
+}
+
+// Schedule returns up to n cycles to be charged from state onwards. Fewer are
+// returned if the subscription expires first.
+func (c *BillingCalculator) Schedule(state BillingState, n int) ([]BillingCycle, error) {
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	billingDay := c.billingDay(state)
+	next := calendarDay(state.NextBillingDate)
+	var cycles []BillingCycle
+	for i := 0; i < n; i++ {
+		number := state.CurrentBillingCycle + i + 1
+		if c.NumberOfBillingCycles > 0 && number > c.NumberOfBillingCycles {
+			break
+		}
+		billingDate := addMonths(next, i*c.BillingFrequency, billingDay)
+		cycles = append(cycles, BillingCycle{
+			Number:        number,
+			BillingDate:   billingDate,
+			PeriodEndDate: addMonths(next, (i+1)*c.BillingFrequency, billingDay).AddDate(0, 0, -1),
+			Amount:        centsDecimal(c.amount(i)),
+		})
+	}
+	return cycles, nil
+}
+
+// Prorate returns the charge for changing the price to newPrice on the day change,
+// which must fall within the current billing period. A negative charge is credited
+// to the subscription's balance.
+func (c *BillingCalculator) Prorate(state BillingState, change time.Time, newPrice *Decimal) (*Decimal, error) {
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	if newPrice == nil {
+		return nil, errors.New("new price is required")
+	}
+	billingDay := c.billingDay(state)
+	periodEnd := calendarDay(state.NextBillingDate)
+	periodStart := addMonths(periodEnd, -c.BillingFrequency, billingDay)
+	change = calendarDay(change)
+	if change.Before(periodStart) || !change.Before(periodEnd) {
+		return nil, fmt.Errorf("%s is not within the billing period %s to %s",
+			change.Format("2006-01-02"), periodStart.Format("2006-01-02"), periodEnd.AddDate(0, 0, -1).Format("2006-01-02"))
+	}
+	periodDays := daysBetween(periodStart, periodEnd)
+	remainingDays := daysBetween(change, periodEnd)
+	diff := decimalCents(newPrice) - decimalCents(c.Price)
+	return centsDecimal(divRound(diff*int64(remainingDays), int64(periodDays))), nil
+}
+
+func (c *BillingCalculator) billingDay(state BillingState) int {
+	switch {
+	case state.BillingDayOfMonth > 0:
+		return state.BillingDayOfMonth
+	case c.BillingDayOfMonth > 0:
+		return c.BillingDayOfMonth
+	}
+	return state.NextBillingDate.Day()
+}
+
+// amount returns the amount in cents charged i cycles after the current one.
+func (c *BillingCalculator) amount(i int) int64 {
+	total := decimalCents(c.Price)
+	for _, m := range c.AddOns {
+		total += modificationCents(m, i)
+	}
+	for _, m := range c.Discounts {
+		total -= modificationCents(m, i)
+	}
+	if total < 0 {
+		return 0
+	}
+	return total
+}
+
+func modificationCents(m Modification, i int) int64 {
+	if m.Amount == nil {
+		return 0
+	}
+	if !m.NeverExpires && m.NumberOfBillingCycles > 0 && m.CurrentBillingCycle+i >= m.NumberOfBillingCycles {
+		return 0
+	}
+	quantity := m.Quantity
+	if quantity == 0 {
+		quantity = 1
+	}
+	return decimalCents(m.Amount) * int64(quantity)
+}
+
+// calendarDay truncates t to midnight UTC of its calendar day.
+func calendarDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// addMonths returns the date n months after t on the given day of the month, or on
+// the last day of the month if it is shorter.
+func addMonths(t time.Time, n int, dayOfMonth int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(n), 1, 0, 0, 0, 0, time.UTC)
+	if last := first.AddDate(0, 1, -1).Day(); dayOfMonth > last {
+		dayOfMonth = last
+	}
+	return first.AddDate(0, 0, dayOfMonth-1)
+}
+
+// daysBetween returns the number of days from start up to, but not including, end.
+func daysBetween(start, end time.Time) int {
+	return int(end.Sub(start).Hours()/24 + 0.5)
+}
+
+// decimalCents returns d in cents, rounding half away from zero.
+func decimalCents(d *Decimal) int64 {
+	switch {
+	case d.Scale == 2:
+		return d.Unscaled
+	case d.Scale < 2:
+		cents := d.Unscaled
+		for i := d.Scale; i < 2; i++ {
+			cents *= 10
+		}
+		return cents
+	}
+	divisor := int64(1)
+	for i := 2; i < d.Scale; i++ {
+		divisor *= 10
+	}
+	return divRound(d.Unscaled, divisor)
+}
+
+func centsDecimal(cents int64) *Decimal {
+	return NewDecimal(cents, 2)
+}
+
+// divRound divides a by b, rounding half away from zero.
+func divRound(a, b int64) int64 {
+	q, r := a/b, a%b
+	if r < 0 {
+		r = -r
+	}
+	if 2*r >= b {
+		if a < 0 {
+			q--
+		} else {
+			q++
+		}
+	}
+	return q
+}
@@ -0,0 +1,241 @@
+package braintree
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lionelbarrow/braintree-go/date"
+	"github.com/lionelbarrow/braintree-go/nullable"
+)
+
+func testDate(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestBillingCalculatorStart(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		calculator BillingCalculator
+		start      string
+		want       string
+		wantDay    int
+	}{
+		{"immediately", BillingCalculator{}, "2016-09-11", "2016-09-11", 11},
+		{"end of month", BillingCalculator{}, "2016-01-30", "2016-01-30", 31},
+		{"billing day later this month", BillingCalculator{BillingDayOfMonth: 15}, "2016-09-11", "2016-09-15", 15},
+		{"billing day today", BillingCalculator{BillingDayOfMonth: 11}, "2016-09-11", "2016-09-11", 11},
+		{"billing day next month", BillingCalculator{BillingDayOfMonth: 1}, "2016-09-11", "2016-10-01", 1},
+		{"billing day last of month", BillingCalculator{BillingDayOfMonth: 31}, "2016-02-11", "2016-02-29", 31},
+		{"trial in days", BillingCalculator{TrialDuration: 14, TrialDurationUnit: SubscriptionTrialDurationUnitDay}, "2016-09-25", "2016-10-09", 9},
+		{"trial in months", BillingCalculator{TrialDuration: 1, TrialDurationUnit: SubscriptionTrialDurationUnitMonth}, "2016-01-31", "2016-02-29", 31},
+		{"trial and billing day", BillingCalculator{BillingDayOfMonth: 1, TrialDuration: 7, TrialDurationUnit: SubscriptionTrialDurationUnitDay}, "2016-09-11", "2016-10-01", 1},
+	}
+	for _, tt := range tests {
+		c := tt.calculator
+		c.BillingFrequency = 1
+		c.Price = NewDecimal(1000, 2)
+		state, err := c.Start(testDate(tt.start))
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if got := state.NextBillingDate.Format("2006-01-02"); got != tt.want || state.BillingDayOfMonth != tt.wantDay {
+			t.Errorf("%s: got %s on day %d, want %s on day %d", tt.name, got, state.BillingDayOfMonth, tt.want, tt.wantDay)
+		}
+		if state.CurrentBillingCycle != 0 {
+			t.Errorf("%s: got current billing cycle %d", tt.name, state.CurrentBillingCycle)
+		}
+	}
+}
+
+func TestBillingCalculatorSchedule(t *testing.T) {
+	t.Parallel()
+
+	c := &BillingCalculator{
+		BillingFrequency:      1,
+		NumberOfBillingCycles: 4,
+		Price:                 NewDecimal(2000, 2),
+		AddOns: []Modification{
+			{Id: "seat", Amount: NewDecimal(500, 2), Quantity: 3, NeverExpires: true},
+			{Id: "setup", Amount: NewDecimal(1000, 2), NumberOfBillingCycles: 1},
+		},
+		Discounts: []Modification{
+			{Id: "launch", Amount: NewDecimal(750, 2), NumberOfBillingCycles: 3, CurrentBillingCycle: 1},
+		},
+	}
+	state, err := c.Start(testDate("2016-01-31"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cycles, err := c.Schedule(state, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		billingDate, periodEnd, amount string
+	}{
+		{"2016-01-31", "2016-02-28", "37.50"},
+		{"2016-02-29", "2016-03-30", "27.50"},
+		{"2016-03-31", "2016-04-29", "35.00"},
+		{"2016-04-30", "2016-05-30", "35.00"},
+	}
+	if len(cycles) != len(want) {
+		t.Fatalf("got %d cycles, want %d", len(cycles), len(want))
+	}
+	for i, w := range want {
+		got := cycles[i]
+		if got.Number != i+1 || got.BillingDate.Format("2006-01-02") != w.billingDate ||
+			got.PeriodEndDate.Format("2006-01-02") != w.periodEnd || got.Amount.String() != w.amount {
+			t.Errorf("cycle %d: got %d %s to %s for %s, want %s to %s for %s", i, got.Number,
+				got.BillingDate.Format("2006-01-02"), got.PeriodEndDate.Format("2006-01-02"), got.Amount,
+				w.billingDate, w.periodEnd, w.amount)
+		}
+	}
+}
+
+func TestBillingCalculatorScheduleFrequency(t *testing.T) {
+	t.Parallel()
+
+	c := &BillingCalculator{BillingFrequency: 3, Price: NewDecimal(100, 2)}
+	state := BillingState{CurrentBillingCycle: 5, NextBillingDate: testDate("2016-11-30"), BillingDayOfMonth: 30}
+
+	cycles, err := c.Schedule(state, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"2016-11-30", "2017-02-28", "2017-05-30"}
+	for i, w := range want {
+		if got := cycles[i].BillingDate.Format("2006-01-02"); got != w || cycles[i].Number != 6+i {
+			t.Errorf("cycle %d: got %s number %d, want %s", i, got, cycles[i].Number, w)
+		}
+	}
+}
+
+func TestBillingCalculatorDiscountFloor(t *testing.T) {
+	t.Parallel()
+
+	c := &BillingCalculator{
+		BillingFrequency: 1,
+		Price:            NewDecimal(500, 2),
+		Discounts:        []Modification{{Amount: NewDecimal(1000, 2), NeverExpires: true}},
+	}
+	cycles, err := c.Schedule(BillingState{NextBillingDate: testDate("2016-09-11")}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cycles[0].Amount.String(); got != "0.00" {
+		t.Fatalf("got %s, want 0.00", got)
+	}
+}
+
+func TestBillingCalculatorProrate(t *testing.T) {
+	t.Parallel()
+
+	c := &BillingCalculator{BillingFrequency: 1, Price: NewDecimal(3000, 2)}
+	// The period runs from 2016-09-01 to 2016-09-30.
+	state := BillingState{CurrentBillingCycle: 2, NextBillingDate: testDate("2016-10-01")}
+
+	tests := []struct {
+		change   string
+		newPrice *Decimal
+		want     string
+	}{
+		{"2016-09-01", NewDecimal(6000, 2), "30.00"},
+		{"2016-09-16", NewDecimal(6000, 2), "15.00"},
+		{"2016-09-30", NewDecimal(6000, 2), "1.00"},
+		{"2016-09-21", NewDecimal(1000, 2), "-6.67"},
+		{"2016-09-11", NewDecimal(3000, 2), "0.00"},
+		{"2016-09-11", NewDecimal(4999, 2), "13.33"},
+	}
+	for _, tt := range tests {
+		got, err := c.Prorate(state, testDate(tt.change), tt.newPrice)
+		if err != nil {
+			t.Errorf("%s: %v", tt.change, err)
+			continue
+		}
+		if got.String() != tt.want {
+			t.Errorf("%s to %s: got %s, want %s", tt.change, tt.newPrice, got, tt.want)
+		}
+	}
+
+	for _, change := range []string{"2016-08-31", "2016-10-01"} {
+		if _, err := c.Prorate(state, testDate(change), NewDecimal(6000, 2)); err == nil {
+			t.Errorf("%s: expected an error for a change outside the period", change)
+		}
+	}
+}
+
+func TestBillingCalculatorFromPlan(t *testing.T) {
+	t.Parallel()
+
+	frequency := nullable.NewNullInt64(1, true)
+	cycles := nullable.NewNullInt64(12, true)
+	trial := nullable.NewNullBool(true, true)
+	trialDuration := nullable.NewNullInt64(7, true)
+	plan := &Plan{
+		Id:                    "test_plan",
+		BillingFrequency:      &frequency,
+		NumberOfBillingCycles: &cycles,
+		Price:                 NewDecimal(1000, 2),
+		TrialPeriod:           &trial,
+		TrialDuration:         &trialDuration,
+		TrialDurationUnit:     SubscriptionTrialDurationUnitDay,
+		AddOns:                AddOnList{AddOns: []AddOn{{Modification: Modification{Amount: NewDecimal(100, 2), NeverExpires: true}}}},
+	}
+
+	c := NewBillingCalculator(plan)
+	state, err := c.Start(testDate("2016-09-11"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := state.NextBillingDate.Format("2006-01-02"); got != "2016-09-18" {
+		t.Fatalf("got first billing date %s", got)
+	}
+
+	neverExpires := nullable.NewNullBool(true, true)
+	c.ApplySubscription(&Subscription{
+		Price:        NewDecimal(800, 2),
+		NeverExpires: &neverExpires,
+	})
+	if c.NumberOfBillingCycles != 0 || len(c.AddOns) != 0 {
+		t.Fatalf("expected the subscription to override the plan, got %+v", c)
+	}
+
+	state, err = NewBillingState(&Subscription{
+		CurrentBillingCycle: "12",
+		BillingDayOfMonth:   "31",
+		NextBillingDate:     &date.Date{Time: testDate("2017-02-28")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	schedule, err := c.Schedule(state, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(schedule) != 2 || schedule[1].Number != 14 || schedule[1].BillingDate.Format("2006-01-02") != "2017-03-31" || schedule[1].Amount.String() != "8.00" {
+		t.Fatalf("got %+v", schedule)
+	}
+}
+
+func TestBillingCalculatorInvalid(t *testing.T) {
+	t.Parallel()
+
+	if _, err := (&BillingCalculator{Price: NewDecimal(100, 2)}).Start(testDate("2016-09-11")); err == nil {
+		t.Error("expected an error without a billing frequency")
+	}
+	if _, err := (&BillingCalculator{BillingFrequency: 1}).Schedule(BillingState{NextBillingDate: testDate("2016-09-11")}, 1); err == nil {
+		t.Error("expected an error without a price")
+	}
+	if _, err := NewBillingState(&Subscription{}); err == nil {
+		t.Error("expected an error without a next billing date")
+	}
+}
@@ -2,10 +2,14 @@ package braintree
 
 import (
 	"bytes"
+	"context"
 	"encoding/xml"
 	"fmt"
 	"log"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
 )
 
 type Environment string
@@ -18,16 +22,30 @@ const (
 	LibraryVersion = "0.9.0"
 )
 
-func (e Environment) BaseURL() string {
+// NewEnvironment returns an environment for a gateway reachable at baseURL, such as a
+// local stand-in or an egress proxy. A path in baseURL is kept as a prefix of the
+// merchant path, e.g. "https://proxy.example.com/braintree" results in requests to
+// "https://proxy.example.com/braintree/merchants/{merchant id}/...".
+func NewEnvironment(baseURL string) Environment {
+	return Environment(strings.TrimRight(baseURL, "/"))
+}
+
+// BaseURL returns the URL of the gateway for the environment. It returns an error
+// for environments that are neither predefined nor an absolute http(s) URL.
+func (e Environment) BaseURL() (string, error) {
 	switch e {
 	case Development:
-		return "http://localhost:3000"
+		return "http://localhost:3000", nil
 	case Sandbox:
-		return "https://sandbox.braintreegateway.com"
+		return "https://sandbox.braintreegateway.com", nil
 	case Production:
-		return "https://www.braintreegateway.com"
+		return "https://www.braintreegateway.com", nil
 	}
-	panic(`invalid environment "` + e + `"`)
+	u, err := url.Parse(string(e))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("invalid environment %q", string(e))
+	}
+	return strings.TrimRight(string(e), "/"), nil
 }
 
 type ApiVersion int
@@ -61,77 +79,183 @@ type Braintree struct {
 	MerchantId  string
 	PublicKey   string
 	PrivateKey  string
-	Logger      *log.Logger
 	HttpClient  *http.Client
+	RetryPolicy *RetryPolicy
+	// MaxResponseBodySize limits the size of response bodies, it defaults to
+	// DefaultMaxResponseBodySize.
+	MaxResponseBodySize int64
+
+	// Logger prints every request and response. Sensitive elements are masked,
+	// see RedactElements.
+	Logger *log.Logger
+	// RequestLogger is notified once every call to the gateway has completed.
+	RequestLogger RequestLogger
+	// RedactElements lists XML elements to mask in logs in addition to
+	// DefaultRedactedElements.
+	RedactElements []string
+	// Interceptors wrap every call to the gateway, the first one being the outermost.
+	Interceptors []Interceptor
+}
+
+func (g *Braintree) MerchantURL() (string, error) {
+	baseURL, err := g.Environment.BaseURL()
+	if err != nil {
+		return "", err
+	}
+	return baseURL + "/merchants/" + g.MerchantId, nil
 }
 
-func (g *Braintree) MerchantURL() string {
-	return g.Environment.BaseURL() + "/merchants/" + g.MerchantId
+// execute makes a call on behalf of the named gateway, e.g. "Transaction".
+func (g *Braintree) execute(ctx context.Context, gateway, method, path string, xmlObj interface{}) (*Response, error) {
+	return g.executeVersion(ctx, gateway, method, path, xmlObj, ApiVersion3)
 }
 
-func (g *Braintree) execute(method, path string, xmlObj interface{}) (*Response, error) {
-	return g.executeVersion(method, path, xmlObj, ApiVersion3)
+// executeVersion makes a call on behalf of the named gateway using apiVersion.
+func (g *Braintree) executeVersion(ctx context.Context, gateway, method, path string, xmlObj interface{}, apiVersion ApiVersion) (*Response, error) {
+	return g.executeCall(ctx, &Call{
+		Gateway:    gateway,
+		Method:     method,
+		Path:       path,
+		ApiVersion: apiVersion,
+		Request:    xmlObj,
+	})
 }
 
-func (g *Braintree) executeVersion(method, path string, xmlObj interface{}, apiVersion ApiVersion) (*Response, error) {
-	var buf bytes.Buffer
+// executeCall runs call through the Interceptors and performs it.
+func (g *Braintree) executeCall(ctx context.Context, call *Call) (*Response, error) {
+	return chain(g.Interceptors, g.invoke)(ctx, call)
+}
+
+// invoke performs the call bound to ctx. If ctx is cancelled or its deadline passes,
+// ctx.Err() is returned unwrapped so it can be told apart from API errors.
+// Transient failures are retried according to RetryPolicy.
+func (g *Braintree) invoke(ctx context.Context, call *Call) (*Response, error) {
+	method, path, xmlObj, apiVersion := call.Method, call.Path, call.Request, call.ApiVersion
+
+	start := time.Now()
+
+	var body []byte
 	if xmlObj != nil {
 		xmlBody, err := xml.Marshal(xmlObj)
 		if err != nil {
 			return nil, err
 		}
-		_, err = buf.Write(xmlBody)
-		if err != nil {
-			return nil, err
-		}
+		body = xmlBody
 	}
 
-	url := g.MerchantURL() + "/" + path
+	merchantURL, err := g.MerchantURL()
+	if err != nil {
+		return nil, err
+	}
+	url := merchantURL + "/" + path
+	if len(call.Query) > 0 {
+		url += "?" + call.Query.Encode()
+	}
 
 	if g.Logger != nil {
-		g.Logger.Printf("> %s %s\n%s", method, url, buf.String())
+		g.Logger.Printf("> %s %s\n%s", method, url, g.redact(body))
 	}
 
-	req, err := http.NewRequest(method, url, &buf)
-	if err != nil {
-		return nil, err
+	btr, attempts, err := g.executeAttempts(ctx, method, path, url, xmlObj, body, apiVersion)
+
+	if g.RequestLogger != nil {
+		entry := &RequestLog{
+			Method:      method,
+			Path:        path,
+			ApiVersion:  apiVersion,
+			Attempts:    attempts,
+			Duration:    time.Since(start),
+			RequestBody: g.redact(body),
+			Err:         err,
+		}
+		if btr != nil {
+			entry.Status = btr.StatusCode
+			entry.ResponseBody = g.redact(btr.Body)
+		}
+		g.RequestLogger.LogRequest(ctx, entry)
 	}
 
-	req.Header.Set("Content-Type", "application/xml")
-	req.Header.Set("Accept", "application/xml")
-	req.Header.Set("Accept-Encoding", "gzip")
-	req.Header.Set("User-Agent", fmt.Sprintf("Braintree Go %s", LibraryVersion))
-	req.Header.Set("X-ApiVersion", fmt.Sprintf("%d", apiVersion))
-	req.SetBasicAuth(g.PublicKey, g.PrivateKey)
+	return btr, err
+}
 
-	httpClient := g.HttpClient
-	if httpClient == nil {
-		httpClient = http.DefaultClient
+// executeAttempts sends the request until it succeeds or the retry policy gives up.
+// The returned response is set whenever a body was read, even if err is an API error.
+func (g *Braintree) executeAttempts(ctx context.Context, method, path, url string, xmlObj interface{}, body []byte, apiVersion ApiVersion) (*Response, int, error) {
+	attempts := 1
+	if g.RetryPolicy.canRetry(method, path, xmlObj) {
+		attempts = g.RetryPolicy.attempts()
 	}
 
-	resp, err := httpClient.Do(req)
-	if err != nil {
-		return nil, err
+	var resp *http.Response
+	attempt := 1
+	for ; ; attempt++ {
+		var err error
+		resp, err = g.do(ctx, method, url, body, apiVersion)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, attempt, ctxErr
+			}
+			if attempt >= attempts {
+				return nil, attempt, err
+			}
+		} else if attempt >= attempts || !isRetryableStatus(resp.StatusCode) {
+			break
+		} else {
+			resp.Body.Close()
+		}
+
+		var header http.Header
+		if resp != nil {
+			header = resp.Header
+		}
+		d := g.RetryPolicy.delay(attempt, header)
+		if g.Logger != nil {
+			g.Logger.Printf("retrying %s %s in %s (attempt %d of %d)", method, url, d, attempt+1, attempts)
+		}
+		if err := sleep(ctx, d); err != nil {
+			return nil, attempt, err
+		}
 	}
 	defer resp.Body.Close()
 
 	btr := &Response{
 		Response: resp,
 	}
-	err = btr.unpackBody()
+	err := btr.unpackBody(method, path, g.MaxResponseBodySize)
 	if err != nil {
-		return nil, err
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, attempt, ctxErr
+		}
+		return btr, attempt, err
 	}
 
 	if g.Logger != nil {
-		g.Logger.Printf("<\n%s", string(btr.Body))
+		g.Logger.Printf("<\n%s", g.redact(btr.Body))
 	}
 
-	err = btr.apiError()
+	return btr, attempt, btr.apiError(method, path)
+}
+
+// do sends a single attempt of a request.
+func (g *Braintree) do(ctx context.Context, method, url string, body []byte, apiVersion ApiVersion) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
 	if err != nil {
 		return nil, err
 	}
-	return btr, nil
+
+	req.Header.Set("Content-Type", "application/xml")
+	req.Header.Set("Accept", "application/xml")
+	req.Header.Set("Accept-Encoding", "gzip")
+	req.Header.Set("User-Agent", fmt.Sprintf("Braintree Go %s", LibraryVersion))
+	req.Header.Set("X-ApiVersion", fmt.Sprintf("%d", apiVersion))
+	req.SetBasicAuth(g.PublicKey, g.PrivateKey)
+
+	httpClient := g.HttpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return httpClient.Do(req)
 }
 
 func (g *Braintree) ClientToken() *ClientTokenGateway {
@@ -189,3 +313,7 @@ func (g *Braintree) WebhookNotification() *WebhookNotificationGateway {
 func (g *Braintree) Settlement() *SettlementGateway {
 	return &SettlementGateway{g}
 }
+
+func (g *Braintree) Dispute() *DisputeGateway {
+	return &DisputeGateway{g}
+}
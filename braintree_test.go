@@ -0,0 +1,151 @@
+package braintree
+
+import (
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// blockingClient returns a client whose requests never complete on their own,
+// they only return once the request's context is done.
+func blockingClient() *http.Client {
+	return &http.Client{
+		Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			<-r.Context().Done()
+			return nil, r.Context().Err()
+		}),
+	}
+}
+
+func TestExecuteContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithHttpClient(Sandbox, "merchant-id", "public-key", "private-key", blockingClient())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := g.Transaction().Find(ctx, "some-id")
+	if err != context.Canceled {
+		t.Fatalf("got %#v, want %#v", err, context.Canceled)
+	}
+}
+
+func TestExecuteContextDeadlineExceeded(t *testing.T) {
+	t.Parallel()
+
+	g := NewWithHttpClient(Sandbox, "merchant-id", "public-key", "private-key", blockingClient())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := g.Customer().Find(ctx, "some-id")
+	if err != context.DeadlineExceeded {
+		t.Fatalf("got %#v, want %#v", err, context.DeadlineExceeded)
+	}
+}
+
+// testServerGateway returns a gateway whose requests are all sent to srv.
+func testServerGateway(srv *httptest.Server) *Braintree {
+	return New(NewEnvironment(srv.URL), "merchant-id", "public-key", "private-key")
+}
+
+// testRequestServer returns a server expecting requests with method to path, relative
+// to the merchant URL, e.g. "transactions/abc123/clone". Each request body must equal
+// want; the server answers with status and body.
+func testRequestServer(t *testing.T, method, path, want string, status int, body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != method || r.URL.Path != "/merchants/merchant-id/"+path {
+			t.Errorf("got request %s %s, want %s %s", r.Method, r.URL.Path, method, path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if got, _ := ioutil.ReadAll(r.Body); string(got) != want {
+			t.Errorf("got request %s, want %s", got, want)
+		}
+		writeXML(w, status, body)
+	}))
+}
+
+// writeXML writes a gzipped XML body the way the Braintree gateway does.
+func writeXML(w http.ResponseWriter, status int, body string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.Header().Set("Content-Encoding", "gzip")
+	w.WriteHeader(status)
+	zw := gzip.NewWriter(w)
+	zw.Write([]byte(body))
+	zw.Close()
+}
+
+func TestEnvironmentBaseURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		env     Environment
+		want    string
+		wantErr bool
+	}{
+		{Sandbox, "https://sandbox.braintreegateway.com", false},
+		{Production, "https://www.braintreegateway.com", false},
+		{Development, "http://localhost:3000", false},
+		{NewEnvironment("http://127.0.0.1:54321/"), "http://127.0.0.1:54321", false},
+		{NewEnvironment("https://proxy.example.com/braintree"), "https://proxy.example.com/braintree", false},
+		{Environment("staging"), "", true},
+		{NewEnvironment("ftp://example.com"), "", true},
+		{NewEnvironment("/relative/path"), "", true},
+	}
+	for _, tt := range tests {
+		got, err := tt.env.BaseURL()
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%q: got error %v, want error %v", tt.env, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("%q: got %q, want %q", tt.env, got, tt.want)
+		}
+	}
+}
+
+func TestCustomEnvironmentPathPrefix(t *testing.T) {
+	t.Parallel()
+
+	var gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		writeXML(w, http.StatusOK, `<customer><id>cust-1</id></customer>`)
+	}))
+	defer srv.Close()
+
+	g := New(NewEnvironment(srv.URL+"/egress/braintree"), "merchant-id", "public-key", "private-key")
+
+	c, err := g.Customer().Find(context.Background(), "cust-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Id != "cust-1" {
+		t.Fatalf("got customer %q", c.Id)
+	}
+	if want := "/egress/braintree/merchants/merchant-id/customers/cust-1"; gotPath != want {
+		t.Fatalf("got path %q, want %q", gotPath, want)
+	}
+}
+
+func TestInvalidEnvironment(t *testing.T) {
+	t.Parallel()
+
+	g := New(Environment("staging"), "merchant-id", "public-key", "private-key")
+
+	_, err := g.Customer().Find(context.Background(), "cust-1")
+	if err == nil {
+		t.Fatal("expected an error for an invalid environment")
+	}
+}
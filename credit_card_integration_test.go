@@ -1,19 +1,22 @@
 package braintree
 
 import (
+	"context"
 	"testing"
 )
 
 func TestCreditCard(t *testing.T) {
 	t.Parallel()
 
-	cust, err := testGateway.Customer().Create(&Customer{})
+	ctx := context.Background()
+
+	cust, err := testGateway.Customer().Create(ctx, &Customer{})
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	g := testGateway.CreditCard()
-	card, err := g.Create(&CreditCard{
+	card, err := g.Create(ctx, &CreditCard{
 		CustomerId:     cust.Id,
 		Number:         testCreditCards["visa"].Number,
 		ExpirationDate: "05/14",
@@ -33,7 +36,7 @@ func TestCreditCard(t *testing.T) {
 	}
 
 	// Update
-	card2, err := g.Update(&CreditCard{
+	card2, err := g.Update(ctx, &CreditCard{
 		Token:          card.Token,
 		Number:         testCreditCards["mastercard"].Number,
 		ExpirationDate: "05/14",
@@ -56,7 +59,7 @@ func TestCreditCard(t *testing.T) {
 	}
 
 	// Delete
-	err = g.Delete(card2)
+	err = g.Delete(ctx, card2)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -65,11 +68,13 @@ func TestCreditCard(t *testing.T) {
 func TestCreateCreditCardWithExpirationMonthAndYear(t *testing.T) {
 	t.Parallel()
 
-	customer, err := testGateway.Customer().Create(&Customer{})
+	ctx := context.Background()
+
+	customer, err := testGateway.Customer().Create(ctx, &Customer{})
 	if err != nil {
 		t.Fatal(err)
 	}
-	card, err := testGateway.CreditCard().Create(&CreditCard{
+	card, err := testGateway.CreditCard().Create(ctx, &CreditCard{
 		CustomerId:      customer.Id,
 		Number:          testCreditCards["visa"].Number,
 		ExpirationMonth: "05",
@@ -88,7 +93,9 @@ func TestCreateCreditCardWithExpirationMonthAndYear(t *testing.T) {
 func TestCreateCreditCardInvalidInput(t *testing.T) {
 	t.Parallel()
 
-	card, err := testGateway.CreditCard().Create(&CreditCard{
+	ctx := context.Background()
+
+	card, err := testGateway.CreditCard().Create(ctx, &CreditCard{
 		Number:         testCreditCards["visa"].Number,
 		ExpirationDate: "05/14",
 	})
@@ -106,11 +113,13 @@ func TestCreateCreditCardInvalidInput(t *testing.T) {
 func TestFindCreditCard(t *testing.T) {
 	t.Parallel()
 
-	customer, err := testGateway.Customer().Create(&Customer{})
+	ctx := context.Background()
+
+	customer, err := testGateway.Customer().Create(ctx, &Customer{})
 	if err != nil {
 		t.Fatal(err)
 	}
-	card, err := testGateway.CreditCard().Create(&CreditCard{
+	card, err := testGateway.CreditCard().Create(ctx, &CreditCard{
 		CustomerId:     customer.Id,
 		Number:         testCreditCards["visa"].Number,
 		ExpirationDate: "05/14",
@@ -129,7 +138,7 @@ func TestFindCreditCard(t *testing.T) {
 		t.Fatal("invalid token")
 	}
 
-	card2, err := testGateway.CreditCard().Find(card.Token)
+	card2, err := testGateway.CreditCard().Find(ctx, card.Token)
 
 	t.Log(card2)
 
@@ -144,7 +153,9 @@ func TestFindCreditCard(t *testing.T) {
 func TestFindCreditCardBadData(t *testing.T) {
 	t.Parallel()
 
-	card, err := testGateway.CreditCard().Find("invalid_token")
+	ctx := context.Background()
+
+	card, err := testGateway.CreditCard().Find(ctx, "invalid_token")
 
 	t.Log(card)
 
@@ -156,11 +167,13 @@ func TestFindCreditCardBadData(t *testing.T) {
 func TestSaveCreditCardWithVenmoSDKPaymentMethodCode(t *testing.T) {
 	t.Parallel()
 
-	customer, err := testGateway.Customer().Create(&Customer{})
+	ctx := context.Background()
+
+	customer, err := testGateway.Customer().Create(ctx, &Customer{})
 	if err != nil {
 		t.Fatal(err)
 	}
-	card, err := testGateway.CreditCard().Create(&CreditCard{
+	card, err := testGateway.CreditCard().Create(ctx, &CreditCard{
 		CustomerId:                customer.Id,
 		VenmoSDKPaymentMethodCode: "stub-" + testCreditCards["visa"].Number,
 	})
@@ -175,11 +188,13 @@ func TestSaveCreditCardWithVenmoSDKPaymentMethodCode(t *testing.T) {
 func TestSaveCreditCardWithVenmoSDKSession(t *testing.T) {
 	t.Parallel()
 
-	customer, err := testGateway.Customer().Create(&Customer{})
+	ctx := context.Background()
+
+	customer, err := testGateway.Customer().Create(ctx, &Customer{})
 	if err != nil {
 		t.Fatal(err)
 	}
-	card, err := testGateway.CreditCard().Create(&CreditCard{
+	card, err := testGateway.CreditCard().Create(ctx, &CreditCard{
 		CustomerId:     customer.Id,
 		Number:         testCreditCards["visa"].Number,
 		ExpirationDate: "05/14",
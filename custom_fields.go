@@ -0,0 +1,75 @@
+package braintree
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// CustomFields holds the values of custom fields defined in the Braintree control
+// panel, keyed by their API name, e.g. "order_reference". Only fields marked as
+// "store and return" are returned by the gateway. The gateway returns the names
+// dashed, e.g. "order-reference", they are decoded back to underscores.
+type CustomFields map[string]string
+
+// MarshalXML encodes each field as an element named after it, in order of name.
+func (c CustomFields) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	names := make([]string, 0, len(c))
+	for name := range c {
+		if !validXMLName(name) {
+			return fmt.Errorf("invalid custom field name %q", name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, name := range names {
+		if err := e.EncodeElement(c[name], xml.StartElement{Name: xml.Name{Local: name}}); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+func (c *CustomFields) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	fields := CustomFields{}
+	for {
+		t, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := t.(type) {
+		case xml.StartElement:
+			var value string
+			if err := d.DecodeElement(&value, &t); err != nil {
+				return err
+			}
+			fields[strings.Replace(t.Name.Local, "-", "_", -1)] = value
+		case xml.EndElement:
+			*c = fields
+			return nil
+		}
+	}
+}
+
+// validXMLName reports whether name can be used as the name of an XML element
+// without a namespace.
+func validXMLName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		switch {
+		case unicode.IsLetter(r) || r == '_':
+		case i > 0 && (unicode.IsDigit(r) || r == '-' || r == '.'):
+		default:
+			return false
+		}
+	}
+	return !strings.HasPrefix(strings.ToLower(name), "xml")
+}
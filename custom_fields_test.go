@@ -0,0 +1,90 @@
+package braintree
+
+import (
+	"encoding/xml"
+	"reflect"
+	"testing"
+)
+
+func TestCustomFieldsMarshalXML(t *testing.T) {
+	t.Parallel()
+
+	tx := &Transaction{
+		Amount:       NewDecimal(1000, 2),
+		CustomFields: CustomFields{"tenant_id": "acme", "order_reference": "A&B-1"},
+	}
+	b, err := xml.Marshal(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := `<transaction><amount>10.00</amount><custom-fields><order_reference>A&amp;B-1</order_reference><tenant_id>acme</tenant_id></custom-fields></transaction>`
+	if string(b) != expect {
+		t.Fatalf("got %s, want %s", b, expect)
+	}
+
+	b, err = xml.Marshal(&Customer{Id: "c1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect := `<customer><id>c1</id></customer>`; string(b) != expect {
+		t.Fatalf("got %s, want %s", b, expect)
+	}
+
+	for _, name := range []string{"", "bad name", "1st", "a<b", "ns:name"} {
+		if _, err := xml.Marshal(&Customer{CustomFields: CustomFields{name: "x"}}); err == nil {
+			t.Errorf("expected an error for the field name %q", name)
+		}
+	}
+}
+
+func TestCustomFieldsUnmarshalXML(t *testing.T) {
+	t.Parallel()
+
+	var c Customer
+	err := xml.Unmarshal([]byte(`<customer>
+  <id>c1</id>
+  <custom-fields>
+    <tenant-id>acme</tenant-id>
+    <order-reference nil="true"></order-reference>
+  </custom-fields>
+</customer>`), &c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := CustomFields{"tenant_id": "acme", "order_reference": ""}
+	if !reflect.DeepEqual(c.CustomFields, want) {
+		t.Fatalf("got %v, want %v", c.CustomFields, want)
+	}
+
+	var tx Transaction
+	if err := xml.Unmarshal([]byte(`<transaction><custom-fields/></transaction>`), &tx); err != nil {
+		t.Fatal(err)
+	}
+	if tx.CustomFields == nil || len(tx.CustomFields) != 0 {
+		t.Fatalf("got %#v, want empty custom fields", tx.CustomFields)
+	}
+}
+
+func TestCustomFieldSearch(t *testing.T) {
+	t.Parallel()
+
+	s := new(TransactionSearch)
+	s.Id().Is("abc123")
+	s.CustomField("id").Is("tenant-7")
+	s.CustomField("tenant_id").StartsWith("acme")
+
+	b, err := xml.Marshal(s.Query())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := `<search><id><is>abc123</is></id><id><is>tenant-7</is></id><tenant_id><starts-with>acme</starts-with></tenant_id></search>`
+	if string(b) != expect {
+		t.Fatalf("got %s, want %s", b, expect)
+	}
+
+	c := new(CustomerSearch)
+	c.CustomField("bad name").Is("x")
+	if _, err := xml.Marshal(c.Query()); err == nil {
+		t.Fatal("expected an error for an invalid custom field name")
+	}
+}
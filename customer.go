@@ -15,6 +15,7 @@ type Customer struct {
 	CreditCard     *CreditCard     `xml:"credit-card,omitempty"`
 	CreditCards    *CreditCards    `xml:"credit-cards,omitempty"`
 	PayPalAccounts *PayPalAccounts `xml:"paypal-accounts,omitempty"`
+	CustomFields   CustomFields    `xml:"custom-fields,omitempty"`
 }
 
 // PaymentMethods returns a slice of all PaymentMethods this customer has
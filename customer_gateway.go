@@ -1,6 +1,9 @@
 package braintree
 
-import "encoding/xml"
+import (
+	"context"
+	"encoding/xml"
+)
 
 type CustomerGateway struct {
 	*Braintree
@@ -8,8 +11,8 @@ type CustomerGateway struct {
 
 // Create creates a new customer from the passed in customer object.
 // If no Id is set, Braintree will assign one.
-func (g *CustomerGateway) Create(c *Customer) (*Customer, error) {
-	resp, err := g.execute("POST", "customers", c)
+func (g *CustomerGateway) Create(ctx context.Context, c *Customer) (*Customer, error) {
+	resp, err := g.execute(ctx, "Customer", "POST", "customers", c)
 	if err != nil {
 		return nil, err
 	}
@@ -22,8 +25,8 @@ func (g *CustomerGateway) Create(c *Customer) (*Customer, error) {
 
 // Update updates any field that is set in the passed customer object.
 // The Id field is mandatory.
-func (g *CustomerGateway) Update(c *Customer) (*Customer, error) {
-	resp, err := g.execute("PUT", "customers/"+c.Id, c)
+func (g *CustomerGateway) Update(ctx context.Context, c *Customer) (*Customer, error) {
+	resp, err := g.execute(ctx, "Customer", "PUT", "customers/"+c.Id, c)
 	if err != nil {
 		return nil, err
 	}
@@ -35,8 +38,8 @@ func (g *CustomerGateway) Update(c *Customer) (*Customer, error) {
 }
 
 // Find finds the customer with the given id.
-func (g *CustomerGateway) Find(id string) (*Customer, error) {
-	resp, err := g.execute("GET", "customers/"+id, nil)
+func (g *CustomerGateway) Find(ctx context.Context, id string) (*Customer, error) {
+	resp, err := g.execute(ctx, "Customer", "GET", "customers/"+id, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -47,8 +50,10 @@ func (g *CustomerGateway) Find(id string) (*Customer, error) {
 	return nil, &invalidResponseError{resp}
 }
 
-func (g *CustomerGateway) Search(query *SearchQuery) (*CustomerSearchResult, error) {
-	resp, err := g.execute("POST", "customers/advanced_search", query)
+// Search finds the customers matching the search query. Only the first page of
+// results is returned, use SearchAll to walk all of them.
+func (g *CustomerGateway) Search(ctx context.Context, query *SearchQuery) (*CustomerSearchResult, error) {
+	resp, err := g.execute(ctx, "Customer", "POST", "customers/advanced_search", query)
 	if err != nil {
 		return nil, err
 	}
@@ -60,9 +65,30 @@ func (g *CustomerGateway) Search(query *SearchQuery) (*CustomerSearchResult, err
 	return &v, err
 }
 
+// SearchAll finds all customers matching the search query. Unlike Search it is not
+// limited to a single page of results: the returned iterator fetches further pages
+// as it advances.
+func (g *CustomerGateway) SearchAll(ctx context.Context, query *SearchQuery) (*CustomerIterator, error) {
+	it, err := newSearchIterator(ctx, g.Braintree, "Customer", "customers", query, func(ctx context.Context, ids []string) ([]interface{}, error) {
+		result, err := g.Search(ctx, idsQuery(ids))
+		if err != nil {
+			return nil, err
+		}
+		items := make([]interface{}, len(result.Customers))
+		for i, c := range result.Customers {
+			items[i] = c
+		}
+		return items, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &CustomerIterator{it}, nil
+}
+
 // Delete deletes the customer with the given id.
-func (g *CustomerGateway) Delete(id string) error {
-	resp, err := g.execute("DELETE", "customers/"+id, nil)
+func (g *CustomerGateway) Delete(ctx context.Context, id string) error {
+	resp, err := g.execute(ctx, "Customer", "DELETE", "customers/"+id, nil)
 	if err != nil {
 		return err
 	}
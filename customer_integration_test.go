@@ -1,6 +1,8 @@
 package braintree
 
 import (
+	"context"
+	"errors"
 	"reflect"
 	"testing"
 
@@ -11,6 +13,8 @@ import (
 func TestCustomer(t *testing.T) {
 	t.Parallel()
 
+	ctx := context.Background()
+
 	oc := &Customer{
 		FirstName: "Lionel",
 		LastName:  "Barrow",
@@ -30,7 +34,7 @@ func TestCustomer(t *testing.T) {
 	}
 
 	// Create with errors
-	_, err := testGateway.Customer().Create(oc)
+	_, err := testGateway.Customer().Create(ctx, oc)
 	if err == nil {
 		t.Fatal("Did not receive error when creating invalid customer")
 	}
@@ -38,7 +42,7 @@ func TestCustomer(t *testing.T) {
 	// Create
 	oc.CreditCard.CVV = ""
 	oc.CreditCard.Options = nil
-	customer, err := testGateway.Customer().Create(oc)
+	customer, err := testGateway.Customer().Create(ctx, oc)
 
 	t.Log(customer)
 
@@ -58,7 +62,7 @@ func TestCustomer(t *testing.T) {
 	// Update
 	unique := testhelpers.RandomString()
 	newFirstName := "John" + unique
-	c2, err := testGateway.Customer().Update(&Customer{
+	c2, err := testGateway.Customer().Update(ctx, &Customer{
 		Id:        customer.Id,
 		FirstName: newFirstName,
 	})
@@ -73,7 +77,7 @@ func TestCustomer(t *testing.T) {
 	}
 
 	// Find
-	c3, err := testGateway.Customer().Find(customer.Id)
+	c3, err := testGateway.Customer().Find(ctx, customer.Id)
 
 	t.Log(c3)
 
@@ -85,10 +89,9 @@ func TestCustomer(t *testing.T) {
 	}
 
 	// Search
-	query := new(SearchQuery)
-	f := query.AddTextField("first-name")
-	f.Is = newFirstName
-	searchResult, err := testGateway.Customer().Search(query)
+	query := new(CustomerSearch)
+	query.FirstName().Is(newFirstName)
+	searchResult, err := testGateway.Customer().Search(ctx, query.Query())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -100,19 +103,22 @@ func TestCustomer(t *testing.T) {
 	}
 
 	// Delete
-	err = testGateway.Customer().Delete(customer.Id)
+	err = testGateway.Customer().Delete(ctx, customer.Id)
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	// Test customer 404
-	c4, err := testGateway.Customer().Find(customer.Id)
+	c4, err := testGateway.Customer().Find(ctx, customer.Id)
 	if err == nil {
 		t.Fatal("should return 404")
 	}
 	if err.Error() != "Not Found (404)" {
 		t.Fatal(err)
 	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatal(err)
+	}
 	if c4 != nil {
 		t.Fatal(c4)
 	}
@@ -121,14 +127,16 @@ func TestCustomer(t *testing.T) {
 func TestCustomerPayPalAccount(t *testing.T) {
 	t.Parallel()
 
-	customer, err := testGateway.Customer().Create(&Customer{})
+	ctx := context.Background()
+
+	customer, err := testGateway.Customer().Create(ctx, &Customer{})
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	nonce := FakeNoncePayPalFuturePayment
 
-	paymentMethod, err := testGateway.PaymentMethod().Create(&PaymentMethodRequest{
+	paymentMethod, err := testGateway.PaymentMethod().Create(ctx, &PaymentMethodRequest{
 		CustomerId:         customer.Id,
 		PaymentMethodNonce: nonce,
 	})
@@ -137,7 +145,7 @@ func TestCustomerPayPalAccount(t *testing.T) {
 	}
 	paypalAccount := paymentMethod.(*PayPalAccount)
 
-	customerFound, err := testGateway.Customer().Find(customer.Id)
+	customerFound, err := testGateway.Customer().Find(ctx, customer.Id)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -153,19 +161,21 @@ func TestCustomerPayPalAccount(t *testing.T) {
 func TestCustomerPaymentMethods(t *testing.T) {
 	t.Parallel()
 
-	customer, err := testGateway.Customer().Create(&Customer{})
+	ctx := context.Background()
+
+	customer, err := testGateway.Customer().Create(ctx, &Customer{})
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	paymentMethod1, err := testGateway.PaymentMethod().Create(&PaymentMethodRequest{
+	paymentMethod1, err := testGateway.PaymentMethod().Create(ctx, &PaymentMethodRequest{
 		CustomerId:         customer.Id,
 		PaymentMethodNonce: FakeNoncePayPalFuturePayment,
 	})
 	if err != nil {
 		t.Fatal(err)
 	}
-	paymentMethod2, err := testGateway.PaymentMethod().Create(&PaymentMethodRequest{
+	paymentMethod2, err := testGateway.PaymentMethod().Create(ctx, &PaymentMethodRequest{
 		CustomerId:         customer.Id,
 		PaymentMethodNonce: FakeNonceTransactable,
 	})
@@ -178,7 +188,7 @@ func TestCustomerPaymentMethods(t *testing.T) {
 		paymentMethod1,
 	}
 
-	customerFound, err := testGateway.Customer().Find(customer.Id)
+	customerFound, err := testGateway.Customer().Find(ctx, customer.Id)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -191,19 +201,21 @@ func TestCustomerPaymentMethods(t *testing.T) {
 func TestCustomerDefaultPaymentMethod(t *testing.T) {
 	t.Parallel()
 
-	customer, err := testGateway.Customer().Create(&Customer{})
+	ctx := context.Background()
+
+	customer, err := testGateway.Customer().Create(ctx, &Customer{})
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	defaultPaymentMethod, err := testGateway.PaymentMethod().Create(&PaymentMethodRequest{
+	defaultPaymentMethod, err := testGateway.PaymentMethod().Create(ctx, &PaymentMethodRequest{
 		CustomerId:         customer.Id,
 		PaymentMethodNonce: FakeNonceTransactable,
 	})
 	if err != nil {
 		t.Fatal(err)
 	}
-	_, err = testGateway.PaymentMethod().Create(&PaymentMethodRequest{
+	_, err = testGateway.PaymentMethod().Create(ctx, &PaymentMethodRequest{
 		CustomerId:         customer.Id,
 		PaymentMethodNonce: FakeNoncePayPalFuturePayment,
 	})
@@ -211,7 +223,7 @@ func TestCustomerDefaultPaymentMethod(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	customerFound, err := testGateway.Customer().Find(customer.Id)
+	customerFound, err := testGateway.Customer().Find(ctx, customer.Id)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -224,26 +236,28 @@ func TestCustomerDefaultPaymentMethod(t *testing.T) {
 func TestCustomerDefaultPaymentMethodManuallySet(t *testing.T) {
 	t.Parallel()
 
-	customer, err := testGateway.Customer().Create(&Customer{})
+	ctx := context.Background()
+
+	customer, err := testGateway.Customer().Create(ctx, &Customer{})
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	_, err = testGateway.PaymentMethod().Create(&PaymentMethodRequest{
+	_, err = testGateway.PaymentMethod().Create(ctx, &PaymentMethodRequest{
 		CustomerId:         customer.Id,
 		PaymentMethodNonce: FakeNonceTransactable,
 	})
 	if err != nil {
 		t.Fatal(err)
 	}
-	paymentMethod2, err := testGateway.PaymentMethod().Create(&PaymentMethodRequest{
+	paymentMethod2, err := testGateway.PaymentMethod().Create(ctx, &PaymentMethodRequest{
 		CustomerId:         customer.Id,
 		PaymentMethodNonce: FakeNoncePayPalFuturePayment,
 	})
 	if err != nil {
 		t.Fatal(err)
 	}
-	paypalAccount, err := testGateway.PayPalAccount().Update(&PayPalAccount{
+	paypalAccount, err := testGateway.PayPalAccount().Update(ctx, &PayPalAccount{
 		Token: paymentMethod2.GetToken(),
 		Options: &PayPalAccountOptions{
 			MakeDefault: true,
@@ -253,7 +267,7 @@ func TestCustomerDefaultPaymentMethodManuallySet(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	customerFound, err := testGateway.Customer().Find(customer.Id)
+	customerFound, err := testGateway.Customer().Find(ctx, customer.Id)
 	if err != nil {
 		t.Fatal(err)
 	}
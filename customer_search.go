@@ -0,0 +1,122 @@
+package braintree
+
+// CustomerSearch builds a customer search query from typed criteria, e.g.
+//
+//	s := new(CustomerSearch)
+//	s.Email().EndsWith("@example.com")
+//	s.CreatedAt().AtLeast(start)
+//	result, err := bt.Customer().Search(ctx, s.Query())
+//
+// Each field only offers the operators Braintree supports for it. Criteria without
+// a value are left out of the query.
+type CustomerSearch struct {
+	searchCriteria
+}
+
+func (s *CustomerSearch) Id() *TextCriterion {
+	return s.text("id")
+}
+
+// Ids matches any of the given customer ids.
+func (s *CustomerSearch) Ids() *MultipleValueCriterion {
+	return s.multipleValue("ids")
+}
+
+func (s *CustomerSearch) Email() *TextCriterion {
+	return s.text("email")
+}
+
+func (s *CustomerSearch) FirstName() *TextCriterion {
+	return s.text("first-name")
+}
+
+func (s *CustomerSearch) LastName() *TextCriterion {
+	return s.text("last-name")
+}
+
+func (s *CustomerSearch) Company() *TextCriterion {
+	return s.text("company")
+}
+
+func (s *CustomerSearch) Phone() *TextCriterion {
+	return s.text("phone")
+}
+
+func (s *CustomerSearch) Fax() *TextCriterion {
+	return s.text("fax")
+}
+
+func (s *CustomerSearch) Website() *TextCriterion {
+	return s.text("website")
+}
+
+func (s *CustomerSearch) CreatedAt() *TimeRangeCriterion {
+	return s.timeRange("created-at")
+}
+
+// PaymentMethodToken matches customers with a payment method with the given token.
+func (s *CustomerSearch) PaymentMethodToken() *TextCriterion {
+	return s.text("payment-method-token")
+}
+
+// PaymentMethodTokenWithDuplicates matches customers with a payment method with the
+// given token, or with a duplicate of it.
+func (s *CustomerSearch) PaymentMethodTokenWithDuplicates() *ExactMatchCriterion {
+	return s.exactMatch("payment-method-token-with-duplicates")
+}
+
+func (s *CustomerSearch) CreditCardNumber() *PartialMatchCriterion {
+	return s.partialMatch("credit-card-number")
+}
+
+// CreditCardExpirationDate matches expiration dates formatted as MM/YYYY.
+func (s *CustomerSearch) CreditCardExpirationDate() *EqualityCriterion {
+	return s.equality("credit-card-expiration-date")
+}
+
+func (s *CustomerSearch) CardholderName() *TextCriterion {
+	return s.text("cardholder-name")
+}
+
+func (s *CustomerSearch) AddressFirstName() *TextCriterion {
+	return s.text("address-first-name")
+}
+
+func (s *CustomerSearch) AddressLastName() *TextCriterion {
+	return s.text("address-last-name")
+}
+
+func (s *CustomerSearch) AddressStreetAddress() *TextCriterion {
+	return s.text("address-street-address")
+}
+
+func (s *CustomerSearch) AddressExtendedAddress() *TextCriterion {
+	return s.text("address-extended-address")
+}
+
+func (s *CustomerSearch) AddressLocality() *TextCriterion {
+	return s.text("address-locality")
+}
+
+func (s *CustomerSearch) AddressRegion() *TextCriterion {
+	return s.text("address-region")
+}
+
+func (s *CustomerSearch) AddressPostalCode() *TextCriterion {
+	return s.text("address-postal-code")
+}
+
+func (s *CustomerSearch) AddressCountryName() *TextCriterion {
+	return s.text("address-country-name")
+}
+
+func (s *CustomerSearch) PayPalAccountEmail() *TextCriterion {
+	return s.text("paypal-account-email")
+}
+
+// CustomField matches the value of the custom field with the given API name. Only
+// fields marked as searchable in the control panel can be searched. Searching fails
+// if name is not a valid XML element name.
+func (s *CustomerSearch) CustomField(name string) *TextCriterion {
+	return s.customField(name)
+}
@@ -0,0 +1,31 @@
+package braintree
+
+import (
+	"encoding/xml"
+	"reflect"
+	"testing"
+)
+
+func TestCustomerSearchPaymentMethodTokenWithDuplicates(t *testing.T) {
+	t.Parallel()
+
+	s := new(CustomerSearch)
+	s.PaymentMethodTokenWithDuplicates().Is("token1")
+
+	b, err := xml.Marshal(s.Query())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := `<search><payment-method-token-with-duplicates><is>token1</is></payment-method-token-with-duplicates></search>`
+	if string(b) != expect {
+		t.Fatalf("got %s, want %s", b, expect)
+	}
+
+	// Braintree only accepts is for this field, so no other operator is offered.
+	typ := reflect.TypeOf(s.PaymentMethodTokenWithDuplicates())
+	for _, op := range []string{"IsNot", "StartsWith", "EndsWith", "Contains"} {
+		if _, ok := typ.MethodByName(op); ok {
+			t.Errorf("%s is offered by %v", op, typ)
+		}
+	}
+}
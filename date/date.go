@@ -12,10 +12,15 @@ type Date struct {
 }
 
 // UnmarshalXML interprets Braintree's date format from XML to initialize the Date
-// e.g. "2014-02-09"
+// e.g. "2014-02-09". Empty elements, such as those marked nil="true", leave the
+// Date zero.
 func (d *Date) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
 	var v string
 	dec.DecodeElement(&v, &start)
+	if v == "" {
+		*d = Date{}
+		return nil
+	}
 
 	parse, err := time.Parse("2006-01-02", v)
 	if err != nil {
@@ -21,6 +21,22 @@ func TestDateUnmarshalXML(t *testing.T) {
 	}
 }
 
+func TestDateUnmarshalXMLNil(t *testing.T) {
+	t.Parallel()
+
+	var v struct {
+		Date *Date `xml:"paid-through-date"`
+	}
+	dateXML := []byte(`<subscription><paid-through-date nil="true"></paid-through-date></subscription>`)
+	if err := xml.Unmarshal(dateXML, &v); err != nil {
+		t.Fatal(err)
+	}
+
+	if v.Date == nil || !v.Date.IsZero() {
+		t.Fatalf("expected a zero date got %v", v.Date)
+	}
+}
+
 func TestDateMarshalXML(t *testing.T) {
 	t.Parallel()
 
@@ -1,6 +1,7 @@
 package braintree
 
 import (
+	"context"
 	"encoding/xml"
 	"github.com/lionelbarrow/braintree-go/date"
 )
@@ -33,12 +34,12 @@ const (
 	None                     = "none"
 )
 
-func (d *Disbursement) Transactions(g *TransactionGateway) (*TransactionSearchResult, error) {
+func (d *Disbursement) Transactions(ctx context.Context, g *TransactionGateway) (*TransactionSearchResult, error) {
 	query := new(SearchQuery)
 	f := query.AddMultiField("ids")
 	f.Items = d.TransactionIds
 
-	result, err := g.Search(query)
+	result, err := g.search(ctx, "Disbursement", query)
 	if err != nil {
 		return nil, err
 	}
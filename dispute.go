@@ -0,0 +1,110 @@
+package braintree
+
+import (
+	"time"
+
+	"github.com/lionelbarrow/braintree-go/date"
+	"github.com/lionelbarrow/braintree-go/nullable"
+)
+
+const (
+	DisputeStatusAccepted = "accepted"
+	DisputeStatusDisputed = "disputed"
+	DisputeStatusExpired  = "expired"
+	DisputeStatusOpen     = "open"
+	DisputeStatusLost     = "lost"
+	DisputeStatusWon      = "won"
+)
+
+const (
+	DisputeKindChargeback     = "chargeback"
+	DisputeKindPreArbitration = "pre_arbitration"
+	DisputeKindRetrieval      = "retrieval"
+)
+
+const (
+	DisputeReasonCancelledRecurringTransaction = "cancelled_recurring_transaction"
+	DisputeReasonCreditNotProcessed            = "credit_not_processed"
+	DisputeReasonDuplicate                     = "duplicate"
+	DisputeReasonFraud                         = "fraud"
+	DisputeReasonGeneral                       = "general"
+	DisputeReasonInvalidAccount                = "invalid_account"
+	DisputeReasonNotRecognized                 = "not_recognized"
+	DisputeReasonProductNotReceived            = "product_not_received"
+	DisputeReasonProductUnsatisfactory         = "product_unsatisfactory"
+	DisputeReasonTransactionAmountDiffers      = "transaction_amount_differs"
+)
+
+// Dispute is a chargeback, pre-arbitration or retrieval request opened by a
+// cardholder's bank against a transaction.
+type Dispute struct {
+	XMLName           string                `xml:"dispute"`
+	Id                string                `xml:"id"`
+	Kind              string                `xml:"kind"`
+	Status            string                `xml:"status"`
+	Reason            string                `xml:"reason"`
+	ReasonCode        string                `xml:"reason-code"`
+	ReasonDescription string                `xml:"reason-description"`
+	Amount            *Decimal              `xml:"amount"`
+	AmountDisputed    *Decimal              `xml:"amount-disputed"`
+	AmountWon         *Decimal              `xml:"amount-won"`
+	CurrencyISOCode   string                `xml:"currency-iso-code"`
+	CaseNumber        string                `xml:"case-number"`
+	ReferenceNumber   string                `xml:"reference-number"`
+	MerchantAccountId string                `xml:"merchant-account-id"`
+	OriginalDisputeId string                `xml:"original-dispute-id"`
+	ProcessorComments string                `xml:"processor-comments"`
+	DateOpened        *date.Date            `xml:"date-opened"`
+	DateWon           *date.Date            `xml:"date-won"`
+	ReceivedDate      *date.Date            `xml:"received-date"`
+	ReplyByDate       *date.Date            `xml:"reply-by-date"`
+	CreatedAt         *time.Time            `xml:"created-at"`
+	UpdatedAt         *time.Time            `xml:"updated-at"`
+	Evidence          []*DisputeEvidence    `xml:"evidence>evidence"`
+	StatusHistory     []*DisputeStatusEvent `xml:"status-history>status-history"`
+	Transaction       *DisputeTransaction   `xml:"transaction"`
+}
+
+// DisputeEvidence is a comment or document submitted to contest a dispute.
+type DisputeEvidence struct {
+	Id string `xml:"id"`
+	// Comment is the text of text evidence.
+	Comment string `xml:"comment"`
+	// URL locates the document of file evidence.
+	URL               string     `xml:"url"`
+	Category          string     `xml:"category"`
+	SequenceNumber    string     `xml:"sequence-number"`
+	CreatedAt         *time.Time `xml:"created-at"`
+	SentToProcessorAt *date.Date `xml:"sent-to-processor-at"`
+}
+
+// DisputeStatusEvent records a change of a dispute's status.
+type DisputeStatusEvent struct {
+	Status           string     `xml:"status"`
+	Timestamp        *time.Time `xml:"timestamp"`
+	EffectiveDate    *date.Date `xml:"effective-date"`
+	DisbursementDate *date.Date `xml:"disbursement-date"`
+}
+
+// DisputeTransaction summarizes the disputed transaction.
+type DisputeTransaction struct {
+	Id                       string     `xml:"id"`
+	Amount                   *Decimal   `xml:"amount"`
+	OrderId                  string     `xml:"order-id"`
+	PurchaseOrderNumber      string     `xml:"purchase-order-number"`
+	PaymentInstrumentSubtype string     `xml:"payment-instrument-subtype"`
+	CreatedAt                *time.Time `xml:"created-at"`
+}
+
+type DisputeList struct {
+	XMLName  string     `xml:"disputes"`
+	Disputes []*Dispute `xml:"dispute"`
+}
+
+type DisputeSearchResult struct {
+	XMLName           string              `xml:"disputes"`
+	CurrentPageNumber *nullable.NullInt64 `xml:"current-page-number"`
+	PageSize          *nullable.NullInt64 `xml:"page-size"`
+	TotalItems        *nullable.NullInt64 `xml:"total-items"`
+	Disputes          []*Dispute          `xml:"dispute"`
+}
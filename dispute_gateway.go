@@ -0,0 +1,125 @@
+package braintree
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// DisputeGateway manages the disputes opened against the merchant's transactions.
+// Disputes can only be contested while open, by adding evidence and then finalizing
+// them, or accepted to concede the disputed amount.
+type DisputeGateway struct {
+	*Braintree
+}
+
+// Find finds the dispute with the specified id.
+func (g *DisputeGateway) Find(ctx context.Context, id string) (*Dispute, error) {
+	resp, err := g.executeVersion(ctx, "Dispute", "GET", "disputes/"+id, nil, ApiVersion4)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case 200:
+		return resp.dispute()
+	}
+	return nil, &invalidResponseError{resp}
+}
+
+// Search finds the disputes matching the search query, see DisputeSearch. Unlike
+// other searches, dispute results are paged by the gateway: page counts from 1, and
+// the result's PageSize and TotalItems tell how many pages there are.
+func (g *DisputeGateway) Search(ctx context.Context, query *SearchQuery, page int) (*DisputeSearchResult, error) {
+	if page < 1 {
+		return nil, fmt.Errorf("invalid page %d", page)
+	}
+	resp, err := g.executeCall(ctx, &Call{
+		Gateway:    "Dispute",
+		Method:     "POST",
+		Path:       "disputes/advanced_search",
+		Query:      url.Values{"page": {strconv.Itoa(page)}},
+		ApiVersion: ApiVersion4,
+		Request:    query,
+	})
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case 200:
+		var v DisputeSearchResult
+		if err := xml.Unmarshal(resp.Body, &v); err != nil {
+			return nil, err
+		}
+		return &v, nil
+	}
+	return nil, &invalidResponseError{resp}
+}
+
+// Accept concedes the dispute with the specified id, which must be open.
+func (g *DisputeGateway) Accept(ctx context.Context, id string) error {
+	return g.update(ctx, "disputes/"+id+"/accept")
+}
+
+// Finalize submits the evidence added to the dispute with the specified id to the
+// processor. No evidence can be added or removed afterwards.
+func (g *DisputeGateway) Finalize(ctx context.Context, id string) error {
+	return g.update(ctx, "disputes/"+id+"/finalize")
+}
+
+func (g *DisputeGateway) update(ctx context.Context, path string) error {
+	resp, err := g.executeVersion(ctx, "Dispute", "PUT", path, nil, ApiVersion4)
+	if err != nil {
+		return err
+	}
+	switch resp.StatusCode {
+	case 200:
+		return nil
+	}
+	return &invalidResponseError{resp}
+}
+
+// AddTextEvidence adds a comment contesting the dispute with the specified id.
+func (g *DisputeGateway) AddTextEvidence(ctx context.Context, id, content string) (*DisputeEvidence, error) {
+	return g.addEvidence(ctx, id, &struct {
+		XMLName string `xml:"comments"`
+		Content string `xml:",chardata"`
+	}{Content: content})
+}
+
+// AddFileEvidence adds a document contesting the dispute with the specified id. The
+// document must have been uploaded to Braintree beforehand, documentId is the id of
+// the upload.
+func (g *DisputeGateway) AddFileEvidence(ctx context.Context, id, documentId string) (*DisputeEvidence, error) {
+	return g.addEvidence(ctx, id, &struct {
+		XMLName    string `xml:"document-upload-id"`
+		DocumentId string `xml:",chardata"`
+	}{DocumentId: documentId})
+}
+
+func (g *DisputeGateway) addEvidence(ctx context.Context, id string, evidence interface{}) (*DisputeEvidence, error) {
+	resp, err := g.executeVersion(ctx, "Dispute", "POST", "disputes/"+id+"/evidence", evidence, ApiVersion4)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case 200, 201:
+		return resp.disputeEvidence()
+	}
+	return nil, &invalidResponseError{resp}
+}
+
+// RemoveEvidence removes evidence from the dispute with the specified id, which must
+// not be finalized yet.
+func (g *DisputeGateway) RemoveEvidence(ctx context.Context, id, evidenceId string) error {
+	resp, err := g.executeVersion(ctx, "Dispute", "DELETE", "disputes/"+id+"/evidence/"+evidenceId, nil, ApiVersion4)
+	if err != nil {
+		return err
+	}
+	switch resp.StatusCode {
+	case 200:
+		return nil
+	}
+	return &invalidResponseError{resp}
+}
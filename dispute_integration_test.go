@@ -0,0 +1,106 @@
+package braintree
+
+import (
+	"context"
+	"testing"
+)
+
+// createDispute creates a transaction with the sandbox card that is always disputed
+// and returns its dispute.
+func createDispute(t *testing.T) *Dispute {
+	tx, err := testGateway.Transaction().Create(context.Background(), &Transaction{
+		Type:   "sale",
+		Amount: NewDecimal(1000, 2),
+		CreditCard: &CreditCard{
+			Number:         testCreditCards["dispute"].Number,
+			ExpirationDate: "12/20",
+		},
+		Options: &TransactionOptions{SubmitForSettlement: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Disputes == nil || len(tx.Disputes.Disputes) != 1 {
+		t.Fatalf("got disputes %+v", tx.Disputes)
+	}
+	return tx.Disputes.Disputes[0]
+}
+
+func TestDisputeFindAndSearch(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dispute := createDispute(t)
+
+	found, err := testGateway.Dispute().Find(ctx, dispute.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.Status != DisputeStatusOpen || found.Transaction == nil || found.ReplyByDate == nil {
+		t.Fatalf("got %+v", found)
+	}
+
+	s := new(DisputeSearch)
+	s.Id().Is(dispute.Id)
+	result, err := testGateway.Dispute().Search(ctx, s.Query(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Disputes) != 1 || result.Disputes[0].Id != dispute.Id {
+		t.Fatalf("got %+v", result.Disputes)
+	}
+}
+
+func TestDisputeEvidenceAndFinalize(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dispute := createDispute(t)
+
+	evidence, err := testGateway.Dispute().AddTextEvidence(ctx, dispute.Id, "Delivered to the cardholder.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if evidence.Comment != "Delivered to the cardholder." {
+		t.Fatalf("got %+v", evidence)
+	}
+	if err := testGateway.Dispute().RemoveEvidence(ctx, dispute.Id, evidence.Id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := testGateway.Dispute().AddTextEvidence(ctx, dispute.Id, "Tracking number 1Z999."); err != nil {
+		t.Fatal(err)
+	}
+	if err := testGateway.Dispute().Finalize(ctx, dispute.Id); err != nil {
+		t.Fatal(err)
+	}
+
+	finalized, err := testGateway.Dispute().Find(ctx, dispute.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if finalized.Status != DisputeStatusDisputed || len(finalized.Evidence) != 1 {
+		t.Fatalf("got status %q, %d evidence", finalized.Status, len(finalized.Evidence))
+	}
+}
+
+func TestDisputeAccept(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dispute := createDispute(t)
+
+	if err := testGateway.Dispute().Accept(ctx, dispute.Id); err != nil {
+		t.Fatal(err)
+	}
+	accepted, err := testGateway.Dispute().Find(ctx, dispute.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if accepted.Status != DisputeStatusAccepted {
+		t.Fatalf("got status %q", accepted.Status)
+	}
+
+	if err := testGateway.Dispute().Accept(ctx, dispute.Id); err == nil {
+		t.Fatal("expected an error accepting a dispute twice")
+	}
+}
@@ -0,0 +1,70 @@
+package braintree
+
+// DisputeSearch builds a dispute search query from typed criteria, e.g.
+//
+//	s := new(DisputeSearch)
+//	s.Status().In(DisputeStatusOpen)
+//	s.ReceivedDate().AtLeast(start)
+//	result, err := bt.Dispute().Search(ctx, s.Query(), 1)
+//
+// Criteria without a value are left out of the query.
+type DisputeSearch struct {
+	searchCriteria
+}
+
+func (s *DisputeSearch) Id() *TextCriterion {
+	return s.text("id")
+}
+
+func (s *DisputeSearch) CaseNumber() *TextCriterion {
+	return s.text("case-number")
+}
+
+func (s *DisputeSearch) ReferenceNumber() *TextCriterion {
+	return s.text("reference-number")
+}
+
+// TransactionId matches disputes of the transaction with the given id.
+func (s *DisputeSearch) TransactionId() *TextCriterion {
+	return s.text("transaction-id")
+}
+
+// Status matches the dispute status, see the DisputeStatus constants.
+func (s *DisputeSearch) Status() *MultipleValueCriterion {
+	return s.multipleValue("status")
+}
+
+// Kind matches the dispute kind, see the DisputeKind constants.
+func (s *DisputeSearch) Kind() *MultipleValueCriterion {
+	return s.multipleValue("kind")
+}
+
+// Reason matches the dispute reason, see the DisputeReason constants.
+func (s *DisputeSearch) Reason() *MultipleValueCriterion {
+	return s.multipleValue("reason")
+}
+
+// ReasonCode matches the reason code given by the card network.
+func (s *DisputeSearch) ReasonCode() *MultipleValueCriterion {
+	return s.multipleValue("reason-code")
+}
+
+func (s *DisputeSearch) MerchantAccountId() *MultipleValueCriterion {
+	return s.multipleValue("merchant-account-id")
+}
+
+func (s *DisputeSearch) AmountDisputed() *DecimalRangeCriterion {
+	return s.decimalRange("amount-disputed")
+}
+
+func (s *DisputeSearch) AmountWon() *DecimalRangeCriterion {
+	return s.decimalRange("amount-won")
+}
+
+func (s *DisputeSearch) ReceivedDate() *DateRangeCriterion {
+	return s.dateRange("received-date")
+}
+
+func (s *DisputeSearch) ReplyByDate() *DateRangeCriterion {
+	return s.dateRange("reply-by-date")
+}
@@ -0,0 +1,261 @@
+package braintree
+
+import (
+	"context"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const testDisputeXML = `<dispute>
+  <id>dispute1</id>
+  <kind>chargeback</kind>
+  <status>open</status>
+  <reason>fraud</reason>
+  <reason-code>83</reason-code>
+  <reason-description>Fraud - card absent environment</reason-description>
+  <amount>100.00</amount>
+  <amount-disputed>100.00</amount-disputed>
+  <amount-won>0.00</amount-won>
+  <currency-iso-code>USD</currency-iso-code>
+  <case-number>CASE-1</case-number>
+  <reference-number>REF-1</reference-number>
+  <merchant-account-id>merchant_account</merchant-account-id>
+  <original-dispute-id nil="true"/>
+  <processor-comments nil="true"/>
+  <date-opened type="date">2017-06-16</date-opened>
+  <date-won nil="true"/>
+  <received-date type="date">2017-06-16</received-date>
+  <reply-by-date type="date">2017-06-30</reply-by-date>
+  <created-at type="datetime">2017-06-16T20:44:41Z</created-at>
+  <updated-at type="datetime">2017-06-21T20:44:41Z</updated-at>
+  <evidence type="array">
+    <evidence>
+      <id>evidence1</id>
+      <comment>Delivered to the cardholder.</comment>
+      <url nil="true"/>
+      <created-at type="datetime">2017-06-21T20:44:42Z</created-at>
+      <sent-to-processor-at type="date">2017-06-22</sent-to-processor-at>
+    </evidence>
+    <evidence>
+      <id>evidence2</id>
+      <comment nil="true"/>
+      <url>https://example.com/receipt.pdf</url>
+      <created-at type="datetime">2017-06-21T20:44:43Z</created-at>
+      <sent-to-processor-at nil="true"/>
+    </evidence>
+  </evidence>
+  <status-history type="array">
+    <status-history>
+      <status>open</status>
+      <timestamp type="datetime">2017-06-16T20:44:41Z</timestamp>
+      <effective-date type="date">2017-06-16</effective-date>
+      <disbursement-date nil="true"/>
+    </status-history>
+  </status-history>
+  <transaction>
+    <id>tx1</id>
+    <amount>100.00</amount>
+    <order-id>order-1</order-id>
+    <purchase-order-number nil="true"/>
+    <payment-instrument-subtype>Visa</payment-instrument-subtype>
+    <created-at type="datetime">2017-06-10T20:44:41Z</created-at>
+  </transaction>
+</dispute>`
+
+func TestDisputeUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var d Dispute
+	if err := xml.Unmarshal([]byte(testDisputeXML), &d); err != nil {
+		t.Fatal(err)
+	}
+	if d.Id != "dispute1" || d.Kind != DisputeKindChargeback || d.Status != DisputeStatusOpen || d.Reason != DisputeReasonFraud ||
+		d.ReasonCode != "83" || d.CaseNumber != "CASE-1" || d.MerchantAccountId != "merchant_account" {
+		t.Fatalf("got %+v", d)
+	}
+	if d.AmountDisputed.Cmp(NewDecimal(10000, 2)) != 0 || d.AmountWon.Cmp(NewDecimal(0, 2)) != 0 {
+		t.Fatalf("got amount disputed %v, won %v", d.AmountDisputed, d.AmountWon)
+	}
+	if d.ReplyByDate.Format("2006-01-02") != "2017-06-30" || d.ReceivedDate.Format("2006-01-02") != "2017-06-16" ||
+		d.DateWon == nil || !d.DateWon.IsZero() {
+		t.Fatalf("got reply by %v, received %v, won %v", d.ReplyByDate, d.ReceivedDate, d.DateWon)
+	}
+
+	if len(d.Evidence) != 2 {
+		t.Fatalf("got %d evidence, want 2", len(d.Evidence))
+	}
+	if e := d.Evidence[0]; e.Id != "evidence1" || e.Comment != "Delivered to the cardholder." || e.SentToProcessorAt.Format("2006-01-02") != "2017-06-22" {
+		t.Fatalf("got %+v", e)
+	}
+	if e := d.Evidence[1]; e.URL != "https://example.com/receipt.pdf" || e.Comment != "" {
+		t.Fatalf("got %+v", e)
+	}
+
+	if len(d.StatusHistory) != 1 {
+		t.Fatalf("got %d status events, want 1", len(d.StatusHistory))
+	}
+	if e := d.StatusHistory[0]; e.Status != DisputeStatusOpen || !e.Timestamp.Equal(time.Date(2017, time.June, 16, 20, 44, 41, 0, time.UTC)) ||
+		e.EffectiveDate.Format("2006-01-02") != "2017-06-16" {
+		t.Fatalf("got %+v", e)
+	}
+
+	if tx := d.Transaction; tx == nil || tx.Id != "tx1" || tx.OrderId != "order-1" || tx.Amount.Cmp(NewDecimal(10000, 2)) != 0 ||
+		tx.PaymentInstrumentSubtype != "Visa" {
+		t.Fatalf("got transaction %+v", d.Transaction)
+	}
+}
+
+func TestTransactionUnmarshalDisputes(t *testing.T) {
+	t.Parallel()
+
+	var tx Transaction
+	err := xml.Unmarshal([]byte(`<transaction><id>tx1</id><disputes type="array">`+testDisputeXML+`</disputes></transaction>`), &tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Disputes == nil || len(tx.Disputes.Disputes) != 1 || tx.Disputes.Disputes[0].Id != "dispute1" {
+		t.Fatalf("got disputes %+v", tx.Disputes)
+	}
+
+	b, err := xml.Marshal(&Transaction{Type: TransactionTypeSale})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `<transaction><type>sale</type></transaction>`; string(b) != want {
+		t.Fatalf("got %s, want %s", b, want)
+	}
+}
+
+func TestDisputeGateway(t *testing.T) {
+	t.Parallel()
+
+	requests := map[string]string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if v := r.Header.Get("X-ApiVersion"); v != "4" {
+			t.Errorf("got api version %q for %s", v, r.URL.Path)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		call := r.Method + " " + r.URL.RequestURI()
+		requests[call] = string(body)
+		switch call {
+		case "GET /merchants/merchant-id/disputes/dispute1":
+			writeXML(w, http.StatusOK, testDisputeXML)
+		case "POST /merchants/merchant-id/disputes/advanced_search?page=2":
+			writeXML(w, http.StatusOK, `<disputes type="collection"><current-page-number type="integer">2</current-page-number><page-size type="integer">10</page-size><total-items type="integer">11</total-items>`+testDisputeXML+`</disputes>`)
+		case "PUT /merchants/merchant-id/disputes/dispute1/accept", "PUT /merchants/merchant-id/disputes/dispute1/finalize",
+			"DELETE /merchants/merchant-id/disputes/dispute1/evidence/evidence1":
+			writeXML(w, http.StatusOK, `<success type="boolean">true</success>`)
+		case "POST /merchants/merchant-id/disputes/dispute1/evidence":
+			writeXML(w, http.StatusOK, `<evidence><id>evidence3</id><comment>Tracking number 1Z999</comment></evidence>`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	g := testServerGateway(srv).Dispute()
+
+	d, err := g.Find(ctx, "dispute1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Id != "dispute1" || d.Transaction.Id != "tx1" {
+		t.Fatalf("got %+v", d)
+	}
+
+	s := new(DisputeSearch)
+	s.Status().In(DisputeStatusOpen)
+	result, err := g.Search(ctx, s.Query(), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.TotalItems.Int64 != 11 || result.CurrentPageNumber.Int64 != 2 || len(result.Disputes) != 1 {
+		t.Fatalf("got %+v", result)
+	}
+	want := `<search><status type="array"><item>open</item></status></search>`
+	if got := requests["POST /merchants/merchant-id/disputes/advanced_search?page=2"]; got != want {
+		t.Fatalf("got search request %s, want %s", got, want)
+	}
+
+	evidence, err := g.AddTextEvidence(ctx, "dispute1", "Tracking number 1Z999")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if evidence.Id != "evidence3" || evidence.Comment != "Tracking number 1Z999" {
+		t.Fatalf("got %+v", evidence)
+	}
+	if got, want := requests["POST /merchants/merchant-id/disputes/dispute1/evidence"], `<comments>Tracking number 1Z999</comments>`; got != want {
+		t.Fatalf("got evidence request %s, want %s", got, want)
+	}
+
+	if _, err := g.AddFileEvidence(ctx, "dispute1", "upload1"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := requests["POST /merchants/merchant-id/disputes/dispute1/evidence"], `<document-upload-id>upload1</document-upload-id>`; got != want {
+		t.Fatalf("got evidence request %s, want %s", got, want)
+	}
+
+	if err := g.RemoveEvidence(ctx, "dispute1", "evidence1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Finalize(ctx, "dispute1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Accept(ctx, "dispute1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.Accept(ctx, "missing"); err == nil {
+		t.Fatal("expected an error accepting a missing dispute")
+	}
+}
+
+func TestDisputeSearchPage(t *testing.T) {
+	t.Parallel()
+
+	attempts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.URL.RequestURI(), "/merchants/merchant-id/disputes/advanced_search?page=1"; got != want {
+			t.Errorf("got request %s, want %s", got, want)
+		}
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		writeXML(w, http.StatusOK, `<disputes type="collection"></disputes>`)
+	}))
+	defer srv.Close()
+
+	var paths []string
+	g := testServerGateway(srv)
+	g.RetryPolicy = testRetryPolicy()
+	g.Interceptors = []Interceptor{
+		func(ctx context.Context, call *Call, next Invoker) (*Response, error) {
+			paths = append(paths, call.Path)
+			return next(ctx, call)
+		},
+	}
+
+	ctx := context.Background()
+	if _, err := g.Dispute().Search(ctx, new(DisputeSearch).Query(), 1); err != nil {
+		t.Fatal(err)
+	}
+	if attempts != 2 {
+		t.Fatalf("got %d attempts, want the search to be retried once", attempts)
+	}
+	if len(paths) != 1 || paths[0] != "disputes/advanced_search" {
+		t.Fatalf("got call paths %v", paths)
+	}
+
+	if _, err := g.Dispute().Search(ctx, new(DisputeSearch).Query(), 0); err == nil {
+		t.Fatal("expected page 0 to be rejected")
+	}
+	if attempts != 2 {
+		t.Fatalf("got %d attempts, want no request for page 0", attempts)
+	}
+}
@@ -1,13 +1,21 @@
 /*
 Package braintree is a client library for Braintree.
 
+Every call that talks to the Braintree gateway takes a context.Context as its first argument.
+Cancelling the context, or letting its deadline pass, aborts the call and returns the context's
+error (context.Canceled or context.DeadlineExceeded) rather than an API error.
+
 API errors are intended to be consumed in two ways. One, they can be dealt with as a single unit:
 
-    result, err := gateway.Create(transaction)
+    result, err := gateway.Create(ctx, transaction)
     err.Error() => "A top level error message"
 
 Second, you can drill down to see specific error messages on a field-by-field basis:
 
     err.For("Transaction").On("Base")[0].Message => "A more specific error message"
+
+Errors on nested entities are reached by chaining For, and DeepAll lists every error with its path:
+
+    err.For("Customer").For("CreditCard").For("BillingAddress").On("PostalCode")
 */
 package braintree
@@ -1,16 +1,15 @@
 package braintree
 
-import "strings"
-
-type errorGroup interface {
-	For(string) errorGroup
-	On(string) []FieldError
-}
+import (
+	"encoding/xml"
+	"strings"
+	"unicode"
+)
 
 type BraintreeError struct {
 	statusCode      int
 	XMLName         string           `xml:"api-error-response"`
-	Errors          responseErrors   `xml:"errors"`
+	Errors          ValidationErrors `xml:"errors"`
 	ErrorMessage    string           `xml:"message"`
 	MerchantAccount *MerchantAccount `xml:",omitempty"`
 	Transaction     Transaction      `xml:"transaction"`
@@ -24,66 +23,136 @@ func (e *BraintreeError) StatusCode() int {
 	return e.statusCode
 }
 
+// All returns every validation error in the response, see DeepAll.
 func (e *BraintreeError) All() []FieldError {
-	baseErrors := e.Errors.TransactionErrors.ErrorList.Errors
-	creditCardErrors := e.Errors.TransactionErrors.CreditCardErrors.ErrorList.Errors
-	customerErrors := e.Errors.TransactionErrors.CustomerErrors.ErrorList.Errors
-	allErrors := append(baseErrors, creditCardErrors...)
-	allErrors = append(allErrors, customerErrors...)
-	return allErrors
-}
-
-func (e *BraintreeError) For(item string) errorGroup {
-	switch item {
-	default:
-		return nil
-	case "Transaction":
-		return e.Errors.TransactionErrors
-	}
+	return e.Errors.DeepAll()
 }
 
-func (e *BraintreeError) On(item string) []FieldError {
-	return []FieldError{}
+// DeepAll returns every validation error in the response with its Path set.
+func (e *BraintreeError) DeepAll() []FieldError {
+	return e.Errors.DeepAll()
 }
 
-type responseErrors struct {
-	TransactionErrors responseError `xml:"transaction"`
+// For returns the validation errors of the named entity, e.g. "Transaction" or "Customer".
+func (e *BraintreeError) For(item string) *ValidationErrors {
+	return e.Errors.For(item)
 }
 
-type responseError struct {
-	ErrorList        errorList  `xml:"errors"`
-	CreditCardErrors errorBlock `xml:"credit-card"`
-	CustomerErrors   errorBlock `xml:"customer"`
+// On returns the validation errors on the named top level entity or attribute.
+func (e *BraintreeError) On(item string) []FieldError {
+	return e.Errors.On(item)
+}
+
+// ValidationErrors is a node in the tree of validation errors returned by the gateway.
+// The tree mirrors the nesting of the request, e.g. the errors on the billing address
+// of a customer's credit card are found at For("Customer").For("CreditCard").For("BillingAddress").
+//
+// Names passed to For and On may be given in Go style ("BillingAddress", "PostalCode")
+// or as they appear in the XML ("billing-address", "postal_code").
+type ValidationErrors struct {
+	// Name is the XML element name of the entity, e.g. "credit-card".
+	Name string
+	// Errors are the errors on the entity's own attributes.
+	Errors FieldErrorList
+	// Children are the nested entities that have errors.
+	Children []*ValidationErrors
+}
+
+func (v *ValidationErrors) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	v.Name = start.Name.Local
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local == "errors" {
+				var list errorList
+				if err := d.DecodeElement(&list, &t); err != nil {
+					return err
+				}
+				v.Errors = append(v.Errors, list.Errors...)
+			} else {
+				child := &ValidationErrors{}
+				if err := d.DecodeElement(child, &t); err != nil {
+					return err
+				}
+				v.Children = append(v.Children, child)
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
 }
 
-func (r responseError) For(item string) errorGroup {
-	switch item {
-	default:
-		return nil
-	case "Base":
-		return r.ErrorList.Errors
-	case "Customer":
-		return r.CustomerErrors.ErrorList.Errors
-	case "CreditCard":
-		return r.CreditCardErrors.ErrorList.Errors
+// For returns the errors of the named nested entity. "Base" returns the entity's own
+// errors without any nested ones. If the entity has no errors an empty node is returned,
+// so calls can always be chained.
+func (v *ValidationErrors) For(item string) *ValidationErrors {
+	if v == nil {
+		return &ValidationErrors{}
 	}
+	if item == "Base" {
+		return &ValidationErrors{Name: v.Name, Errors: v.Errors}
+	}
+	if child := v.child(item); child != nil {
+		return child
+	}
+	return &ValidationErrors{Name: xmlName(item, '-')}
 }
 
-func (r responseError) On(item string) []FieldError {
-	switch item {
-	default:
+// On returns the errors on the named attribute. If item names a nested entity its own
+// errors are returned instead, and "Base" returns all of the entity's own errors.
+func (v *ValidationErrors) On(item string) []FieldError {
+	if v == nil {
 		return []FieldError{}
-	case "Base":
-		return r.ErrorList.Errors
-	case "Customer":
-		return r.CustomerErrors.ErrorList.Errors
-	case "CreditCard":
-		return r.CreditCardErrors.ErrorList.Errors
 	}
+	if item == "Base" {
+		return append([]FieldError{}, v.Errors...)
+	}
+	if child := v.child(item); child != nil {
+		return append([]FieldError{}, child.Errors...)
+	}
+	return v.Errors.On(item)
+}
+
+// DeepAll returns the errors of the entity and all nested entities, each with its Path set.
+func (v *ValidationErrors) DeepAll() []FieldError {
+	errors := make([]FieldError, 0)
+	if v != nil {
+		errors = v.deepAll("", errors)
+	}
+	return errors
+}
+
+func (v *ValidationErrors) deepAll(parent string, errors []FieldError) []FieldError {
+	path := parent
+	// The root of the tree is the <errors> element itself, which is left out of paths.
+	if v.Name != "errors" || parent != "" {
+		if path != "" {
+			path += "."
+		}
+		path += v.Name
+	}
+	for _, e := range v.Errors {
+		e.Path = path
+		errors = append(errors, e)
+	}
+	for _, child := range v.Children {
+		errors = child.deepAll(path, errors)
+	}
+	return errors
 }
 
-type errorBlock struct {
-	ErrorList errorList `xml:"errors"`
+func (v *ValidationErrors) child(item string) *ValidationErrors {
+	name := xmlName(item, '-')
+	for _, c := range v.Children {
+		if c.Name == name || c.Name == item {
+			return c
+		}
+	}
+	return nil
 }
 
 type errorList struct {
@@ -92,14 +161,12 @@ type errorList struct {
 
 type FieldErrorList []FieldError
 
-func (f FieldErrorList) For(item string) errorGroup {
-	return nil
-}
-
+// On returns the errors on the named attribute.
 func (f FieldErrorList) On(item string) []FieldError {
+	attribute := xmlName(item, '_')
 	errors := make([]FieldError, 0)
 	for _, e := range f {
-		if strings.ToLower(item) == e.Attribute {
+		if e.Attribute == attribute || e.Attribute == strings.ToLower(item) {
 			errors = append(errors, e)
 		}
 	}
@@ -107,7 +174,35 @@ func (f FieldErrorList) On(item string) []FieldError {
 }
 
 type FieldError struct {
-	Code      string `xml:"code"`
-	Attribute string `xml:"attribute"`
-	Message   string `xml:"message"`
+	Code      ValidationErrorCode `xml:"code"`
+	Attribute string              `xml:"attribute"`
+	Message   string              `xml:"message"`
+	// Path locates the entity the error belongs to, e.g. "customer.credit-card.billing-address".
+	// It is only set on errors returned by DeepAll.
+	Path string `xml:"-"`
+}
+
+// Category returns the category of the error's code.
+func (e FieldError) Category() ResponseCategory {
+	return e.Code.Category()
+}
+
+// xmlName converts a Go style name such as "BillingAddress" or "TaxID" to the
+// lower case form used in the XML, joining words with sep.
+func xmlName(name string, sep rune) string {
+	// Braintree spells PayPal as a single word, e.g. "paypal-account".
+	runes := []rune(strings.Replace(name, "PayPal", "Paypal", -1))
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			prevLower := i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]))
+			nextLower := i > 0 && i+1 < len(runes) && unicode.IsUpper(runes[i-1]) && unicode.IsLower(runes[i+1])
+			if prevLower || nextLower {
+				b.WriteRune(sep)
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
 }
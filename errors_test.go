@@ -2,6 +2,7 @@ package braintree
 
 import (
 	"encoding/xml"
+	"reflect"
 	"testing"
 )
 
@@ -110,3 +111,130 @@ func TestAccessors(t *testing.T) {
 		t.Fatal("Did not get the right base errors")
 	}
 }
+
+var customerErrorXML = []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<api-error-response>
+  <errors>
+    <errors type="array"/>
+    <customer>
+      <errors type="array">
+        <error>
+          <code>81604</code>
+          <attribute type="symbol">email</attribute>
+          <message>Email is an invalid format.</message>
+        </error>
+      </errors>
+      <credit-card>
+        <errors type="array">
+          <error>
+            <code>81707</code>
+            <attribute type="symbol">cvv</attribute>
+            <message>CVV must be 4 digits for American Express and 3 digits for other card types.</message>
+          </error>
+        </errors>
+        <billing-address>
+          <errors type="array">
+            <error>
+              <code>81813</code>
+              <attribute type="symbol">postal_code</attribute>
+              <message>Postal code can only contain letters, numbers, spaces, and hyphens.</message>
+            </error>
+            <error>
+              <code>91803</code>
+              <attribute type="symbol">country_name</attribute>
+              <message>Country name is not an accepted country.</message>
+            </error>
+          </errors>
+        </billing-address>
+      </credit-card>
+    </customer>
+  </errors>
+  <message>Email is an invalid format.</message>
+</api-error-response>`)
+
+func TestErrorsNestedEntities(t *testing.T) {
+	t.Parallel()
+
+	apiErrors := &BraintreeError{}
+	err := xml.Unmarshal(customerErrorXML, apiErrors)
+	if err != nil {
+		t.Fatal("Error unmarshalling: " + err.Error())
+	}
+
+	postalCodeErrors := apiErrors.For("Customer").For("CreditCard").For("BillingAddress").On("PostalCode")
+	if len(postalCodeErrors) != 1 || postalCodeErrors[0].Code != "81813" {
+		t.Fatalf("Did not get the right postal code errors: %#v", postalCodeErrors)
+	}
+
+	cvvErrors := apiErrors.For("customer").For("credit-card").On("cvv")
+	if len(cvvErrors) != 1 || cvvErrors[0].Code != "81707" {
+		t.Fatalf("Did not get the right cvv errors: %#v", cvvErrors)
+	}
+
+	emailErrors := apiErrors.For("Customer").On("Email")
+	if len(emailErrors) != 1 {
+		t.Fatalf("Did not get the right email errors: %#v", emailErrors)
+	}
+
+	if errs := apiErrors.On("Customer"); len(errs) != 1 {
+		t.Fatalf("Did not get the customer errors from BraintreeError.On: %#v", errs)
+	}
+
+	missing := apiErrors.For("Subscription").For("AddOns").On("Amount")
+	if len(missing) != 0 {
+		t.Fatalf("Expected no errors for a missing entity, got %#v", missing)
+	}
+}
+
+func TestErrorsDeepAll(t *testing.T) {
+	t.Parallel()
+
+	apiErrors := &BraintreeError{}
+	err := xml.Unmarshal(customerErrorXML, apiErrors)
+	if err != nil {
+		t.Fatal("Error unmarshalling: " + err.Error())
+	}
+
+	got := map[ValidationErrorCode]string{}
+	for _, e := range apiErrors.DeepAll() {
+		got[e.Code] = e.Path
+	}
+
+	want := map[ValidationErrorCode]string{
+		"81604": "customer",
+		"81707": "customer.credit-card",
+		"81813": "customer.credit-card.billing-address",
+		"91803": "customer.credit-card.billing-address",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	if n := len(apiErrors.For("Customer").For("CreditCard").DeepAll()); n != 3 {
+		t.Fatalf("got %d errors below the credit card, want 3", n)
+	}
+}
+
+func TestXMLName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   string
+		sep  rune
+		want string
+	}{
+		{"CreditCard", '-', "credit-card"},
+		{"BillingAddress", '-', "billing-address"},
+		{"credit-card", '-', "credit-card"},
+		{"PostalCode", '_', "postal_code"},
+		{"Number", '_', "number"},
+		{"SSN", '_', "ssn"},
+		{"TaxID", '_', "tax_id"},
+		{"PayPalAccount", '-', "paypal-account"},
+	}
+	for _, tt := range tests {
+		if got := xmlName(tt.in, tt.sep); got != tt.want {
+			t.Errorf("%q: got %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
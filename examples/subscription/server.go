@@ -35,7 +35,7 @@ func main() {
 	)
 
 	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		clientToken, err := bt.ClientToken().Generate()
+		clientToken, err := bt.ClientToken().Generate(r.Context())
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -53,8 +53,8 @@ func main() {
 			log.Fatal("Payment method nonce is empty")
 		}
 		// You can later search for the user by his ID
-		// customer, err := bt.Customer().Find("CustomerID")
-		customer, err := bt.Customer().Create(&braintree.Customer{
+		// customer, err := bt.Customer().Find(r.Context(), "CustomerID")
+		customer, err := bt.Customer().Create(r.Context(), &braintree.Customer{
 			// You can leave it empty, but, if you've got a user system, I recommend using the user's ID as the client ID
 			// Or, createa a row for Braintree's customer ID
 			Id: "<CustomerID>",
@@ -64,7 +64,7 @@ func main() {
 		}
 
 		// We create a credit card that after generation, gives us the PaymentMethodToken that's needed in the Subscription.Create
-		card, err := bt.CreditCard().Create(&braintree.CreditCard{
+		card, err := bt.CreditCard().Create(r.Context(), &braintree.CreditCard{
 			// The created or existing customer ID
 			CustomerId: customer.Id,
 			// The nonce from the clinet side
@@ -78,7 +78,7 @@ func main() {
 		}
 
 		// Create the subscription and make the user pay
-		subscription, err := bt.Subscription().Create(&braintree.SubscriptionRequest{
+		subscription, err := bt.Subscription().Create(r.Context(), &braintree.SubscriptionRequest{
 			PlanId: "<YourPlanIDShouldGoHere>",
 			// The payment method token generated by the CreditCard.Create
 			PaymentMethodToken: card.Token,
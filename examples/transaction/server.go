@@ -53,7 +53,7 @@ func createTransaction(w http.ResponseWriter, r *http.Request) {
 		},
 	}
 
-	_, err := bt.Transaction().Create(tx)
+	_, err := bt.Transaction().Create(r.Context(), tx)
 
 	if err == nil {
 		fmt.Fprintf(w, "<h1>Success!</h1>")
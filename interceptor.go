@@ -0,0 +1,124 @@
+package braintree
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// Call describes a single call to the gateway as it passes through the interceptor chain.
+type Call struct {
+	// Gateway is the name of the gateway that made the call, e.g. "Transaction" or "Customer".
+	Gateway string
+	Method  string
+	Path    string
+	// Query is appended to the request URL; it is kept out of Path.
+	Query      url.Values
+	ApiVersion ApiVersion
+	// Request is the object marshalled into the request body, or nil.
+	Request interface{}
+}
+
+// Invoker performs a call. On API errors the response is returned alongside the error.
+type Invoker func(ctx context.Context, call *Call) (*Response, error)
+
+// Interceptor wraps a call to the gateway. It may inspect or change the call and the
+// context before passing them on to next, or return without calling next at all.
+type Interceptor func(ctx context.Context, call *Call, next Invoker) (*Response, error)
+
+func chain(interceptors []Interceptor, invoker Invoker) Invoker {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], invoker
+		invoker = func(ctx context.Context, call *Call) (*Response, error) {
+			return interceptor(ctx, call, next)
+		}
+	}
+	return invoker
+}
+
+// TimingFunc receives the outcome of a call and how long it took.
+type TimingFunc func(ctx context.Context, call *Call, resp *Response, err error, d time.Duration)
+
+// TimingInterceptor returns an interceptor reporting the duration of every call to observe.
+func TimingInterceptor(observe TimingFunc) Interceptor {
+	return func(ctx context.Context, call *Call, next Invoker) (*Response, error) {
+		start := time.Now()
+		resp, err := next(ctx, call)
+		observe(ctx, call, resp, err, time.Since(start))
+		return resp, err
+	}
+}
+
+// CallStat holds aggregated metrics for one kind of call.
+type CallStat struct {
+	Calls         int
+	Errors        int
+	TotalDuration time.Duration
+	MaxDuration   time.Duration
+}
+
+// CallStats collects call counts, error counts and latencies per gateway and HTTP method.
+// It is safe for concurrent use.
+type CallStats struct {
+	mu    sync.Mutex
+	stats map[string]*CallStat
+}
+
+// Interceptor returns an interceptor recording every call in s.
+func (s *CallStats) Interceptor() Interceptor {
+	return TimingInterceptor(func(ctx context.Context, call *Call, resp *Response, err error, d time.Duration) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.stats == nil {
+			s.stats = make(map[string]*CallStat)
+		}
+		key := call.Gateway + " " + call.Method
+		stat, ok := s.stats[key]
+		if !ok {
+			stat = &CallStat{}
+			s.stats[key] = stat
+		}
+		stat.Calls++
+		if err != nil {
+			stat.Errors++
+		}
+		stat.TotalDuration += d
+		if d > stat.MaxDuration {
+			stat.MaxDuration = d
+		}
+	})
+}
+
+// Snapshot returns a copy of the collected metrics, keyed by gateway and HTTP method,
+// e.g. "Transaction POST".
+func (s *CallStats) Snapshot() map[string]CallStat {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snapshot := make(map[string]CallStat, len(s.stats))
+	for k, v := range s.stats {
+		snapshot[k] = *v
+	}
+	return snapshot
+}
+
+// Tracer starts a span for every call to the gateway. The returned context is
+// passed on to the rest of the chain, so the span can be propagated.
+type Tracer interface {
+	StartSpan(ctx context.Context, call *Call) (context.Context, Span)
+}
+
+// Span is a traced call to the gateway.
+type Span interface {
+	End(resp *Response, err error)
+}
+
+// TracingInterceptor returns an interceptor wrapping every call in a span started by t.
+func TracingInterceptor(t Tracer) Interceptor {
+	return func(ctx context.Context, call *Call, next Invoker) (*Response, error) {
+		ctx, span := t.StartSpan(ctx, call)
+		resp, err := next(ctx, call)
+		span.End(resp, err)
+		return resp, err
+	}
+}
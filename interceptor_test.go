@@ -0,0 +1,216 @@
+package braintree
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestInterceptorChainOrder(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeXML(w, http.StatusOK, testTransactionXML)
+	}))
+	defer srv.Close()
+
+	var order []string
+	record := func(name string) Interceptor {
+		return func(ctx context.Context, call *Call, next Invoker) (*Response, error) {
+			order = append(order, name+" before")
+			resp, err := next(ctx, call)
+			order = append(order, name+" after")
+			return resp, err
+		}
+	}
+
+	g := testServerGateway(srv)
+	g.Interceptors = []Interceptor{record("outer"), record("inner")}
+
+	if _, err := g.Transaction().Find(context.Background(), "abc123"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"outer before", "inner before", "inner after", "outer after"}
+	if !reflect.DeepEqual(order, want) {
+		t.Fatalf("got %v, want %v", order, want)
+	}
+}
+
+func TestInterceptorFaultInjection(t *testing.T) {
+	t.Parallel()
+
+	injected := errors.New("injected")
+
+	g := New(Sandbox, "merchant-id", "public-key", "private-key")
+	g.HttpClient = blockingClient()
+	g.Interceptors = []Interceptor{
+		func(ctx context.Context, call *Call, next Invoker) (*Response, error) {
+			return nil, injected
+		},
+	}
+
+	_, err := g.Customer().Find(context.Background(), "some-id")
+	if err != injected {
+		t.Fatalf("got %#v, want %#v", err, injected)
+	}
+}
+
+func TestInterceptorSeesCall(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeXML(w, http.StatusUnprocessableEntity, `<api-error-response><message>Amount is required.</message></api-error-response>`)
+	}))
+	defer srv.Close()
+
+	var (
+		gotCall *Call
+		gotResp *Response
+		gotErr  error
+	)
+
+	g := testServerGateway(srv)
+	g.Interceptors = []Interceptor{
+		func(ctx context.Context, call *Call, next Invoker) (*Response, error) {
+			gotCall = call
+			gotResp, gotErr = next(ctx, call)
+			return gotResp, gotErr
+		},
+	}
+
+	tx := &Transaction{Type: "sale"}
+	if _, err := g.Transaction().Create(context.Background(), tx); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if gotCall.Gateway != "Transaction" || gotCall.Method != "POST" || gotCall.Path != "transactions" || gotCall.ApiVersion != ApiVersion3 {
+		t.Fatalf("unexpected call %+v", gotCall)
+	}
+	if gotCall.Request != tx {
+		t.Fatalf("got request %#v, want %#v", gotCall.Request, tx)
+	}
+	if gotResp == nil || gotResp.StatusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("expected the response to be passed along with the error, got %#v", gotResp)
+	}
+	if _, ok := gotErr.(*BraintreeError); !ok {
+		t.Fatalf("got %#v, want a *BraintreeError", gotErr)
+	}
+}
+
+func TestCallGateway(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/merchants/merchant-id/transactions":
+			writeXML(w, http.StatusCreated, testTransactionXML)
+		case "/merchants/merchant-id/transactions/advanced_search":
+			writeXML(w, http.StatusOK, "<credit-card-transactions>"+testTransactionXML+"</credit-card-transactions>")
+		default:
+			writeXML(w, http.StatusOK, testTransactionXML)
+		}
+	}))
+	defer srv.Close()
+
+	var gateways []string
+	g := testServerGateway(srv)
+	g.Interceptors = []Interceptor{
+		func(ctx context.Context, call *Call, next Invoker) (*Response, error) {
+			gateways = append(gateways, call.Gateway)
+			return next(ctx, call)
+		},
+	}
+
+	ctx := context.Background()
+	if _, err := g.Transaction().Find(ctx, "abc123"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.Subscription().RetryCharge(ctx, "sub123", nil, false); err != nil {
+		t.Fatal(err)
+	}
+	d := &Disbursement{TransactionIds: []string{"abc123"}}
+	if _, err := d.Transactions(ctx, g.Transaction()); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"Transaction", "Subscription", "Disbursement"}
+	if !reflect.DeepEqual(gateways, want) {
+		t.Fatalf("got %v, want %v", gateways, want)
+	}
+}
+
+func TestCallStats(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/merchants/merchant-id/transactions/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writeXML(w, http.StatusOK, testTransactionXML)
+	}))
+	defer srv.Close()
+
+	var stats CallStats
+	g := testServerGateway(srv)
+	g.Interceptors = []Interceptor{stats.Interceptor()}
+
+	ctx := context.Background()
+	g.Transaction().Find(ctx, "abc123")
+	g.Transaction().Find(ctx, "missing")
+
+	stat := stats.Snapshot()["Transaction GET"]
+	if stat.Calls != 2 || stat.Errors != 1 {
+		t.Fatalf("got %+v", stat)
+	}
+	if stat.MaxDuration <= 0 || stat.TotalDuration < stat.MaxDuration {
+		t.Fatalf("unexpected durations %+v", stat)
+	}
+}
+
+type testTracer struct {
+	started []string
+	ended   []int
+}
+
+type testSpan struct {
+	t *testTracer
+}
+
+func (t *testTracer) StartSpan(ctx context.Context, call *Call) (context.Context, Span) {
+	t.started = append(t.started, call.Gateway+" "+call.Method)
+	return ctx, &testSpan{t}
+}
+
+func (s *testSpan) End(resp *Response, err error) {
+	status := 0
+	if resp != nil {
+		status = resp.StatusCode
+	}
+	s.t.ended = append(s.t.ended, status)
+}
+
+func TestTracingInterceptor(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeXML(w, http.StatusOK, testTransactionXML)
+	}))
+	defer srv.Close()
+
+	tracer := &testTracer{}
+	g := testServerGateway(srv)
+	g.Interceptors = []Interceptor{TracingInterceptor(tracer)}
+
+	if _, err := g.Transaction().Void(context.Background(), "abc123"); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(tracer.started, []string{"Transaction PUT"}) || !reflect.DeepEqual(tracer.ended, []int{200}) {
+		t.Fatalf("got started %v, ended %v", tracer.started, tracer.ended)
+	}
+}
@@ -1,6 +1,7 @@
 package braintree
 
 import (
+	"context"
 	"encoding/xml"
 	"testing"
 
@@ -12,6 +13,8 @@ var acctId string
 func TestMerchantAccountCreate(t *testing.T) {
 	t.Parallel()
 
+	ctx := context.Background()
+
 	acctId = testhelpers.RandomString()
 	acct := MerchantAccount{
 		MasterMerchantAccountId: testMerchantAccountId,
@@ -40,7 +43,7 @@ func TestMerchantAccountCreate(t *testing.T) {
 	x, _ := xml.Marshal(&acct)
 	t.Log(string(x))
 
-	merchantAccount, err := testGateway.MerchantAccount().Create(&acct)
+	merchantAccount, err := testGateway.MerchantAccount().Create(ctx, &acct)
 
 	t.Log(merchantAccount)
 
@@ -52,7 +55,7 @@ func TestMerchantAccountCreate(t *testing.T) {
 		t.Fatal("invalid merchant account id")
 	}
 
-	ma2, err := testGateway.MerchantAccount().Find(merchantAccount.Id)
+	ma2, err := testGateway.MerchantAccount().Find(ctx, merchantAccount.Id)
 
 	t.Log(ma2)
 
@@ -67,13 +70,15 @@ func TestMerchantAccountCreate(t *testing.T) {
 }
 
 func TestMerchantAccountTransaction(t *testing.T) {
+	ctx := context.Background()
+
 	if acctId == "" {
 		TestMerchantAccountCreate(t)
 	}
 
 	amount := NewDecimal(int64(randomAmount().Scale+500), 2)
 
-	tx, err := testGateway.Transaction().Create(&Transaction{
+	tx, err := testGateway.Transaction().Create(ctx, &Transaction{
 		Type:   "sale",
 		Amount: amount,
 		CreditCard: &CreditCard{
@@ -96,3 +101,61 @@ func TestMerchantAccountTransaction(t *testing.T) {
 		t.Fatal(tx.Status)
 	}
 }
+
+func TestMerchantAccountTransactionHoldInEscrow(t *testing.T) {
+	ctx := context.Background()
+
+	if acctId == "" {
+		TestMerchantAccountCreate(t)
+	}
+
+	tx, err := testGateway.Transaction().Create(ctx, &Transaction{
+		Type:   "sale",
+		Amount: NewDecimal(int64(randomAmount().Scale+500), 2),
+		CreditCard: &CreditCard{
+			Number:         testCreditCards["visa"].Number,
+			ExpirationDate: "05/14",
+		},
+		ServiceFeeAmount:  NewDecimal(500, 2),
+		MerchantAccountId: acctId,
+		Options: &TransactionOptions{
+			SubmitForSettlement: true,
+			HoldInEscrow:        true,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.EscrowStatus != EscrowStatusHoldPending {
+		t.Fatalf("got escrow status %q", tx.EscrowStatus)
+	}
+
+	if _, err := testGateway.Transaction().ReleaseFromEscrow(ctx, tx.Id); err == nil {
+		t.Fatal("expected an error releasing a transaction that is not held yet")
+	} else if escrowErr, ok := err.(*EscrowError); !ok || escrowErr.Code != ErrorCodeTransactionCannotReleaseFromEscrow {
+		t.Fatalf("got %v", err)
+	}
+}
+
+func TestTransactionHoldInEscrowOnMasterMerchantAccount(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	tx, err := testGateway.Transaction().Create(ctx, &Transaction{
+		Type:   "sale",
+		Amount: randomAmount(),
+		CreditCard: &CreditCard{
+			Number:         testCreditCards["visa"].Number,
+			ExpirationDate: "05/14",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = testGateway.Transaction().HoldInEscrow(ctx, tx.Id)
+	if escrowErr, ok := err.(*EscrowError); !ok || escrowErr.Code != ErrorCodeTransactionCannotHoldInEscrow {
+		t.Fatalf("got %v, want an *EscrowError", err)
+	}
+}
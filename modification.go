@@ -1,6 +1,11 @@
 package braintree
 
-import "time"
+import (
+	"encoding/xml"
+	"time"
+
+	"github.com/lionelbarrow/braintree-go/nullable"
+)
 
 const (
 	ModificationKindDiscount = "discount"
@@ -8,12 +13,73 @@ const (
 )
 
 type Modification struct {
-	Id           string     `xml:"id,omitempty"`
-	Amount       *Decimal   `xml:"amount,omitempty"`
-	Description  string     `xml:"description,omitempty"`
-	Kind         string     `xml:"kind,omitempty"`
-	Name         string     `xml:"name,omitempty"`
-	NeverExpires bool       `xml:"never-expires,omitempty"`
-	Quantity     int        `xml:"quantity,omitempty"`
-	UpdatedAt    *time.Time `xml:"updated_at,omitempty"`
+	Id                    string     `xml:"id,omitempty"`
+	Amount                *Decimal   `xml:"amount,omitempty"`
+	Description           string     `xml:"description,omitempty"`
+	Kind                  string     `xml:"kind,omitempty"`
+	Name                  string     `xml:"name,omitempty"`
+	NeverExpires          bool       `xml:"never-expires,omitempty"`
+	Quantity              int        `xml:"quantity,omitempty"`
+	NumberOfBillingCycles int        `xml:"number-of-billing-cycles,omitempty"`
+	CurrentBillingCycle   int        `xml:"current-billing-cycle,omitempty"`
+	UpdatedAt             *time.Time `xml:"updated_at,omitempty"`
+}
+
+// ModificationsRequest changes the add-ons or discounts of a subscription.
+type ModificationsRequest struct {
+	// Add adds add-ons or discounts inherited from those with the given ids.
+	Add []AddModificationRequest
+	// Update changes add-ons or discounts already on the subscription.
+	Update []UpdateModificationRequest
+	// RemoveExistingIds removes the add-ons or discounts with the given ids.
+	RemoveExistingIds []string
+}
+
+// ModificationRequest holds the values of an add-on or discount that can be
+// overridden on a subscription. Unset values are inherited.
+type ModificationRequest struct {
+	Amount                *Decimal            `xml:"amount,omitempty"`
+	Quantity              int                 `xml:"quantity,omitempty"`
+	NumberOfBillingCycles *nullable.NullInt64 `xml:"number-of-billing-cycles,omitempty"`
+	NeverExpires          *nullable.NullBool  `xml:"never-expires,omitempty"`
+}
+
+type AddModificationRequest struct {
+	InheritedFromId string `xml:"inherited-from-id"`
+	ModificationRequest
+}
+
+type UpdateModificationRequest struct {
+	ExistingId string `xml:"existing-id"`
+	ModificationRequest
+}
+
+func (m ModificationsRequest) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	type addList struct {
+		Type  string                   `xml:"type,attr"`
+		Items []AddModificationRequest `xml:"modification"`
+	}
+	type updateList struct {
+		Type  string                      `xml:"type,attr"`
+		Items []UpdateModificationRequest `xml:"modification"`
+	}
+	type removeList struct {
+		Type  string   `xml:"type,attr"`
+		Items []string `xml:"item"`
+	}
+	var v struct {
+		Add    *addList    `xml:"add,omitempty"`
+		Update *updateList `xml:"update,omitempty"`
+		Remove *removeList `xml:"remove,omitempty"`
+	}
+	if len(m.Add) > 0 {
+		v.Add = &addList{"array", m.Add}
+	}
+	if len(m.Update) > 0 {
+		v.Update = &updateList{"array", m.Update}
+	}
+	if len(m.RemoveExistingIds) > 0 {
+		v.Remove = &removeList{"array", m.RemoveExistingIds}
+	}
+	return e.EncodeElement(v, start)
 }
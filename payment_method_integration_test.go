@@ -1,6 +1,7 @@
 package braintree
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"testing"
@@ -10,7 +11,9 @@ import (
 func TestPaymentMethod(t *testing.T) {
 	t.Parallel()
 
-	cust, err := testGateway.Customer().Create(&Customer{})
+	ctx := context.Background()
+
+	cust, err := testGateway.Customer().Create(ctx, &Customer{})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -18,7 +21,7 @@ func TestPaymentMethod(t *testing.T) {
 	g := testGateway.PaymentMethod()
 
 	// Create using credit card
-	paymentMethod, err := g.Create(&PaymentMethodRequest{
+	paymentMethod, err := g.Create(ctx, &PaymentMethodRequest{
 		CustomerId:         cust.Id,
 		PaymentMethodNonce: FakeNonceTransactableVisa,
 	})
@@ -36,7 +39,7 @@ func TestPaymentMethod(t *testing.T) {
 	// Update using different credit card
 	rand.Seed(time.Now().UTC().UnixNano())
 	token := fmt.Sprintf("btgo_test_token_%d", rand.Int()+1)
-	paymentMethod, err = g.Update(paymentMethod.GetToken(), &PaymentMethodRequest{
+	paymentMethod, err = g.Update(ctx, paymentMethod.GetToken(), &PaymentMethodRequest{
 		PaymentMethodNonce: FakeNonceTransactableMasterCard,
 		Token:              token,
 	})
@@ -49,12 +52,12 @@ func TestPaymentMethod(t *testing.T) {
 	}
 
 	// Updating with different payment method type should fail
-	if _, err = g.Update(token, &PaymentMethodRequest{PaymentMethodNonce: FakeNoncePayPalFuturePayment}); err == nil {
+	if _, err = g.Update(ctx, token, &PaymentMethodRequest{PaymentMethodNonce: FakeNoncePayPalFuturePayment}); err == nil {
 		t.Errorf("Updating with a different payment method type should have failed")
 	}
 
 	// Find credit card
-	paymentMethod, err = g.Find(token)
+	paymentMethod, err = g.Find(ctx, token)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -67,12 +70,12 @@ func TestPaymentMethod(t *testing.T) {
 	}
 
 	// Delete credit card
-	if err := g.Delete(token); err != nil {
+	if err := g.Delete(ctx, token); err != nil {
 		t.Fatal(err)
 	}
 
 	// Create using PayPal
-	paymentMethod, err = g.Create(&PaymentMethodRequest{
+	paymentMethod, err = g.Create(ctx, &PaymentMethodRequest{
 		CustomerId:         cust.Id,
 		PaymentMethodNonce: FakeNoncePayPalFuturePayment,
 	})
@@ -81,23 +84,23 @@ func TestPaymentMethod(t *testing.T) {
 	}
 
 	// Find PayPal
-	_, err = g.Find(paymentMethod.GetToken())
+	_, err = g.Find(ctx, paymentMethod.GetToken())
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	// Updating a PayPal account with a different payment method nonce of any kind should fail
-	if _, err = g.Update(paymentMethod.GetToken(), &PaymentMethodRequest{PaymentMethodNonce: FakeNoncePayPalOneTimePayment}); err == nil {
+	if _, err = g.Update(ctx, paymentMethod.GetToken(), &PaymentMethodRequest{PaymentMethodNonce: FakeNoncePayPalOneTimePayment}); err == nil {
 		t.Errorf("Updating a PayPal account with a different nonce should have failed")
 	}
 
 	// Delete PayPal
-	if err := g.Delete(paymentMethod.GetToken()); err != nil {
+	if err := g.Delete(ctx, paymentMethod.GetToken()); err != nil {
 		t.Fatal(err)
 	}
 
 	// Cleanup
-	if err := testGateway.Customer().Delete(cust.Id); err != nil {
+	if err := testGateway.Customer().Delete(ctx, cust.Id); err != nil {
 		t.Fatal(err)
 	}
 }
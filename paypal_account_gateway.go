@@ -1,11 +1,13 @@
 package braintree
 
+import "context"
+
 type PayPalAccountGateway struct {
 	*Braintree
 }
 
-func (g *PayPalAccountGateway) Update(paypalAccount *PayPalAccount) (*PayPalAccount, error) {
-	resp, err := g.executeVersion("PUT", "payment_methods/paypal_account/"+paypalAccount.Token, paypalAccount, ApiVersion4)
+func (g *PayPalAccountGateway) Update(ctx context.Context, paypalAccount *PayPalAccount) (*PayPalAccount, error) {
+	resp, err := g.executeVersion(ctx, "PayPalAccount", "PUT", "payment_methods/paypal_account/"+paypalAccount.Token, paypalAccount, ApiVersion4)
 	if err != nil {
 		return nil, err
 	}
@@ -16,8 +18,8 @@ func (g *PayPalAccountGateway) Update(paypalAccount *PayPalAccount) (*PayPalAcco
 	return nil, &invalidResponseError{resp}
 }
 
-func (g *PayPalAccountGateway) Find(token string) (*PayPalAccount, error) {
-	resp, err := g.executeVersion("GET", "payment_methods/paypal_account/"+token, nil, ApiVersion4)
+func (g *PayPalAccountGateway) Find(ctx context.Context, token string) (*PayPalAccount, error) {
+	resp, err := g.executeVersion(ctx, "PayPalAccount", "GET", "payment_methods/paypal_account/"+token, nil, ApiVersion4)
 	if err != nil {
 		return nil, err
 	}
@@ -28,8 +30,8 @@ func (g *PayPalAccountGateway) Find(token string) (*PayPalAccount, error) {
 	return nil, &invalidResponseError{resp}
 }
 
-func (g *PayPalAccountGateway) Delete(paypalAccount *PayPalAccount) error {
-	resp, err := g.executeVersion("DELETE", "payment_methods/paypal_account/"+paypalAccount.Token, nil, ApiVersion4)
+func (g *PayPalAccountGateway) Delete(ctx context.Context, paypalAccount *PayPalAccount) error {
+	resp, err := g.executeVersion(ctx, "PayPalAccount", "DELETE", "payment_methods/paypal_account/"+paypalAccount.Token, nil, ApiVersion4)
 	if err != nil {
 		return err
 	}
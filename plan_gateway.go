@@ -1,7 +1,9 @@
 package braintree
 
 import (
+	"context"
 	"encoding/xml"
+	"net/http"
 )
 
 type PlanGateway struct {
@@ -9,8 +11,8 @@ type PlanGateway struct {
 }
 
 // All returns all available plans
-func (g *PlanGateway) All() ([]*Plan, error) {
-	resp, err := g.execute("GET", "plans", nil)
+func (g *PlanGateway) All(ctx context.Context) ([]*Plan, error) {
+	resp, err := g.execute(ctx, "Plan", "GET", "plans", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -25,9 +27,10 @@ func (g *PlanGateway) All() ([]*Plan, error) {
 	return nil, &invalidResponseError{resp}
 }
 
-// Find returns the plan with the specified id, or nil
-func (g *PlanGateway) Find(id string) (*Plan, error) {
-	plans, err := g.All()
+// Find returns the plan with the specified id. If there is no such plan, an
+// *HTTPError matching ErrNotFound is returned.
+func (g *PlanGateway) Find(ctx context.Context, id string) (*Plan, error) {
+	plans, err := g.All(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -36,5 +39,9 @@ func (g *PlanGateway) Find(id string) (*Plan, error) {
 			return p, nil
 		}
 	}
-	return nil, nil
+	return nil, &HTTPError{
+		statusCode: http.StatusNotFound,
+		Method:     "GET",
+		Path:       "plans/" + id,
+	}
 }
@@ -1,6 +1,8 @@
 package braintree
 
 import (
+	"context"
+	"errors"
 	"testing"
 )
 
@@ -8,8 +10,10 @@ import (
 func TestPlan(t *testing.T) {
 	t.Parallel()
 
+	ctx := context.Background()
+
 	g := testGateway.Plan()
-	plans, err := g.All()
+	plans, err := g.All(ctx)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -111,7 +115,7 @@ func TestPlan(t *testing.T) {
 	}
 
 	// Find
-	plan2, err := g.Find("test_plan_2")
+	plan2, err := g.Find(ctx, "test_plan_2")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -125,3 +129,17 @@ func TestPlan(t *testing.T) {
 		t.Fatal(plan2.Discounts)
 	}
 }
+
+func TestPlanFindNotFound(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	plan, err := testGateway.Plan().Find(ctx, "no_such_plan")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatal(err)
+	}
+	if plan != nil {
+		t.Fatal(plan)
+	}
+}
@@ -0,0 +1,215 @@
+package braintree
+
+// ResponseCategory classifies processor responses and validation errors, so callers
+// can decide how to react to them without hard-coding codes.
+type ResponseCategory string
+
+const (
+	// ResponseCategoryApproved is an approved authorization.
+	ResponseCategoryApproved ResponseCategory = "approved"
+	// ResponseCategoryHardDecline is a decline that will not succeed if retried with
+	// the same payment method, e.g. an expired or closed card.
+	ResponseCategoryHardDecline ResponseCategory = "hard_decline"
+	// ResponseCategorySoftDecline is a decline that may succeed later with the same
+	// payment method, e.g. insufficient funds.
+	ResponseCategorySoftDecline ResponseCategory = "soft_decline"
+	// ResponseCategoryRetryable is a temporary failure that can be retried right away.
+	ResponseCategoryRetryable ResponseCategory = "retryable"
+	// ResponseCategoryFraud is a decline or rejection because of suspected fraud or
+	// failed card verification.
+	ResponseCategoryFraud ResponseCategory = "fraud"
+	// ResponseCategoryValidation is a request that was rejected as invalid.
+	ResponseCategoryValidation ResponseCategory = "validation"
+	// ResponseCategoryUnknown is used for codes that are not in the catalog.
+	ResponseCategoryUnknown ResponseCategory = "unknown"
+)
+
+// ProcessorResponseCode is the code returned by the processor for an authorization.
+type ProcessorResponseCode int
+
+const (
+	ProcessorResponseApproved                            ProcessorResponseCode = 1000
+	ProcessorResponseApprovedCheckCustomerID             ProcessorResponseCode = 1001
+	ProcessorResponseProcessed                           ProcessorResponseCode = 1002
+	ProcessorResponseApprovedWithRisk                    ProcessorResponseCode = 1003
+	ProcessorResponseApprovedPartialAmount               ProcessorResponseCode = 1004
+	ProcessorResponseDoNotHonor                          ProcessorResponseCode = 2000
+	ProcessorResponseInsufficientFunds                   ProcessorResponseCode = 2001
+	ProcessorResponseLimitExceeded                       ProcessorResponseCode = 2002
+	ProcessorResponseCardholderActivityLimitExceeded     ProcessorResponseCode = 2003
+	ProcessorResponseExpiredCard                         ProcessorResponseCode = 2004
+	ProcessorResponseInvalidCreditCardNumber             ProcessorResponseCode = 2005
+	ProcessorResponseInvalidExpirationDate               ProcessorResponseCode = 2006
+	ProcessorResponseNoAccount                           ProcessorResponseCode = 2007
+	ProcessorResponseCardAccountLengthError              ProcessorResponseCode = 2008
+	ProcessorResponseNoSuchIssuer                        ProcessorResponseCode = 2009
+	ProcessorResponseCardIssuerDeclinedCVV               ProcessorResponseCode = 2010
+	ProcessorResponseVoiceAuthorizationRequired          ProcessorResponseCode = 2011
+	ProcessorResponsePossibleLostCard                    ProcessorResponseCode = 2012
+	ProcessorResponsePossibleStolenCard                  ProcessorResponseCode = 2013
+	ProcessorResponseFraudSuspected                      ProcessorResponseCode = 2014
+	ProcessorResponseTransactionNotAllowed               ProcessorResponseCode = 2015
+	ProcessorResponseDuplicateTransaction                ProcessorResponseCode = 2016
+	ProcessorResponseCardholderStoppedBilling            ProcessorResponseCode = 2017
+	ProcessorResponseCardholderStoppedAllBilling         ProcessorResponseCode = 2018
+	ProcessorResponseInvalidTransaction                  ProcessorResponseCode = 2019
+	ProcessorResponseViolation                           ProcessorResponseCode = 2020
+	ProcessorResponseSecurityViolation                   ProcessorResponseCode = 2021
+	ProcessorResponseUpdatedCardholderAvailable          ProcessorResponseCode = 2022
+	ProcessorResponseFeatureNotSupported                 ProcessorResponseCode = 2023
+	ProcessorResponseCardTypeNotEnabled                  ProcessorResponseCode = 2024
+	ProcessorResponseSetUpErrorMerchant                  ProcessorResponseCode = 2025
+	ProcessorResponseInvalidMerchantID                   ProcessorResponseCode = 2026
+	ProcessorResponseSetUpErrorAmount                    ProcessorResponseCode = 2027
+	ProcessorResponseSetUpErrorHierarchy                 ProcessorResponseCode = 2028
+	ProcessorResponseSetUpErrorCard                      ProcessorResponseCode = 2029
+	ProcessorResponseSetUpErrorTerminal                  ProcessorResponseCode = 2030
+	ProcessorResponseEncryptionError                     ProcessorResponseCode = 2031
+	ProcessorResponseSurchargeNotPermitted               ProcessorResponseCode = 2032
+	ProcessorResponseInconsistentData                    ProcessorResponseCode = 2033
+	ProcessorResponseNoActionTaken                       ProcessorResponseCode = 2034
+	ProcessorResponsePartialApprovalGroupIII             ProcessorResponseCode = 2035
+	ProcessorResponseAuthorizationNotFound               ProcessorResponseCode = 2036
+	ProcessorResponseAlreadyReversed                     ProcessorResponseCode = 2037
+	ProcessorResponseProcessorDeclined                   ProcessorResponseCode = 2038
+	ProcessorResponseInvalidAuthorizationCode            ProcessorResponseCode = 2039
+	ProcessorResponseInvalidStore                        ProcessorResponseCode = 2040
+	ProcessorResponseCallForApproval                     ProcessorResponseCode = 2041
+	ProcessorResponseInvalidClientID                     ProcessorResponseCode = 2042
+	ProcessorResponseDoNotRetryCallIssuer                ProcessorResponseCode = 2043
+	ProcessorResponseCallIssuer                          ProcessorResponseCode = 2044
+	ProcessorResponseInvalidMerchantNumber               ProcessorResponseCode = 2045
+	ProcessorResponseDeclined                            ProcessorResponseCode = 2046
+	ProcessorResponsePickUpCard                          ProcessorResponseCode = 2047
+	ProcessorResponseInvalidAmount                       ProcessorResponseCode = 2048
+	ProcessorResponseInvalidSKUNumber                    ProcessorResponseCode = 2049
+	ProcessorResponseInvalidCreditPlan                   ProcessorResponseCode = 2050
+	ProcessorResponseCardNumberDoesNotMatchPaymentMethod ProcessorResponseCode = 2051
+	ProcessorResponseCardReportedLostOrStolen            ProcessorResponseCode = 2053
+	ProcessorResponseReversalAmountMismatch              ProcessorResponseCode = 2054
+	ProcessorResponseInvalidTransactionDivisionNumber    ProcessorResponseCode = 2055
+	ProcessorResponseTransactionDivisionLimitExceeded    ProcessorResponseCode = 2056
+	ProcessorResponseIssuerRestriction                   ProcessorResponseCode = 2057
+	ProcessorResponseMerchantNotSecureCodeEnabled        ProcessorResponseCode = 2058
+	ProcessorResponseAddressVerificationFailed           ProcessorResponseCode = 2059
+	ProcessorResponseAddressAndCardSecurityCodeFailed    ProcessorResponseCode = 2060
+	ProcessorResponseInvalidTransactionData              ProcessorResponseCode = 2061
+	ProcessorResponseInvalidTaxAmount                    ProcessorResponseCode = 2062
+	ProcessorResponseInvalidCurrencyCode                 ProcessorResponseCode = 2064
+	ProcessorResponseRefundTimeLimitExceeded             ProcessorResponseCode = 2065
+	ProcessorResponseAuthorizationExpired                ProcessorResponseCode = 2067
+	ProcessorResponsePayPalFundingInstrumentDeclined     ProcessorResponseCode = 2074
+	ProcessorResponsePayPalPayerAccountLockedOrClosed    ProcessorResponseCode = 2075
+	ProcessorResponsePayPalPayerCannotPay                ProcessorResponseCode = 2076
+	ProcessorResponsePayPalRiskModelRefused              ProcessorResponseCode = 2077
+	ProcessorResponsePayPalTransactionLimitExceeded      ProcessorResponseCode = 2086
+	ProcessorResponseProcessorNetworkUnavailable         ProcessorResponseCode = 3000
+)
+
+type responseCodeInfo struct {
+	category ResponseCategory
+	message  string
+}
+
+var processorResponses = map[ProcessorResponseCode]responseCodeInfo{
+	ProcessorResponseApproved:                            {ResponseCategoryApproved, "Approved"},
+	ProcessorResponseApprovedCheckCustomerID:             {ResponseCategoryApproved, "Approved, check customer ID"},
+	ProcessorResponseProcessed:                           {ResponseCategoryApproved, "Processed"},
+	ProcessorResponseApprovedWithRisk:                    {ResponseCategoryApproved, "Approved with risk"},
+	ProcessorResponseApprovedPartialAmount:               {ResponseCategoryApproved, "Approved for partial amount"},
+	ProcessorResponseDoNotHonor:                          {ResponseCategorySoftDecline, "Do Not Honor"},
+	ProcessorResponseInsufficientFunds:                   {ResponseCategorySoftDecline, "Insufficient Funds"},
+	ProcessorResponseLimitExceeded:                       {ResponseCategorySoftDecline, "Limit Exceeded"},
+	ProcessorResponseCardholderActivityLimitExceeded:     {ResponseCategorySoftDecline, "Cardholder's Activity Limit Exceeded"},
+	ProcessorResponseExpiredCard:                         {ResponseCategoryHardDecline, "Expired Card"},
+	ProcessorResponseInvalidCreditCardNumber:             {ResponseCategoryHardDecline, "Invalid Credit Card Number"},
+	ProcessorResponseInvalidExpirationDate:               {ResponseCategoryHardDecline, "Invalid Expiration Date"},
+	ProcessorResponseNoAccount:                           {ResponseCategoryHardDecline, "No Account"},
+	ProcessorResponseCardAccountLengthError:              {ResponseCategoryHardDecline, "Card Account Length Error"},
+	ProcessorResponseNoSuchIssuer:                        {ResponseCategoryHardDecline, "No Such Issuer"},
+	ProcessorResponseCardIssuerDeclinedCVV:               {ResponseCategoryFraud, "Card Issuer Declined CVV"},
+	ProcessorResponseVoiceAuthorizationRequired:          {ResponseCategorySoftDecline, "Voice Authorization Required"},
+	ProcessorResponsePossibleLostCard:                    {ResponseCategoryFraud, "Processor Declined - Possible Lost Card"},
+	ProcessorResponsePossibleStolenCard:                  {ResponseCategoryFraud, "Processor Declined - Possible Stolen Card"},
+	ProcessorResponseFraudSuspected:                      {ResponseCategoryFraud, "Processor Declined - Fraud Suspected"},
+	ProcessorResponseTransactionNotAllowed:               {ResponseCategoryHardDecline, "Transaction Not Allowed"},
+	ProcessorResponseDuplicateTransaction:                {ResponseCategorySoftDecline, "Duplicate Transaction"},
+	ProcessorResponseCardholderStoppedBilling:            {ResponseCategoryHardDecline, "Cardholder Stopped Billing"},
+	ProcessorResponseCardholderStoppedAllBilling:         {ResponseCategoryHardDecline, "Cardholder Stopped All Billing"},
+	ProcessorResponseInvalidTransaction:                  {ResponseCategoryHardDecline, "Invalid Transaction"},
+	ProcessorResponseViolation:                           {ResponseCategoryHardDecline, "Violation"},
+	ProcessorResponseSecurityViolation:                   {ResponseCategoryFraud, "Security Violation"},
+	ProcessorResponseUpdatedCardholderAvailable:          {ResponseCategoryHardDecline, "Declined - Updated Cardholder Available"},
+	ProcessorResponseFeatureNotSupported:                 {ResponseCategoryHardDecline, "Processor Does Not Support This Feature"},
+	ProcessorResponseCardTypeNotEnabled:                  {ResponseCategoryHardDecline, "Card Type Not Enabled"},
+	ProcessorResponseSetUpErrorMerchant:                  {ResponseCategoryHardDecline, "Set Up Error - Merchant"},
+	ProcessorResponseInvalidMerchantID:                   {ResponseCategoryHardDecline, "Invalid Merchant ID"},
+	ProcessorResponseSetUpErrorAmount:                    {ResponseCategoryHardDecline, "Set Up Error - Amount"},
+	ProcessorResponseSetUpErrorHierarchy:                 {ResponseCategoryHardDecline, "Set Up Error - Hierarchy"},
+	ProcessorResponseSetUpErrorCard:                      {ResponseCategoryHardDecline, "Set Up Error - Card"},
+	ProcessorResponseSetUpErrorTerminal:                  {ResponseCategoryHardDecline, "Set Up Error - Terminal"},
+	ProcessorResponseEncryptionError:                     {ResponseCategoryRetryable, "Encryption Error"},
+	ProcessorResponseSurchargeNotPermitted:               {ResponseCategoryHardDecline, "Surcharge Not Permitted"},
+	ProcessorResponseInconsistentData:                    {ResponseCategoryHardDecline, "Inconsistent Data"},
+	ProcessorResponseNoActionTaken:                       {ResponseCategorySoftDecline, "No Action Taken"},
+	ProcessorResponsePartialApprovalGroupIII:             {ResponseCategorySoftDecline, "Partial Approval For Amount In Group III Version"},
+	ProcessorResponseAuthorizationNotFound:               {ResponseCategoryHardDecline, "Authorization could not be found"},
+	ProcessorResponseAlreadyReversed:                     {ResponseCategoryHardDecline, "Already Reversed"},
+	ProcessorResponseProcessorDeclined:                   {ResponseCategorySoftDecline, "Processor Declined"},
+	ProcessorResponseInvalidAuthorizationCode:            {ResponseCategoryHardDecline, "Invalid Authorization Code"},
+	ProcessorResponseInvalidStore:                        {ResponseCategoryHardDecline, "Invalid Store"},
+	ProcessorResponseCallForApproval:                     {ResponseCategorySoftDecline, "Declined - Call For Approval"},
+	ProcessorResponseInvalidClientID:                     {ResponseCategoryHardDecline, "Invalid Client ID"},
+	ProcessorResponseDoNotRetryCallIssuer:                {ResponseCategoryHardDecline, "Error - Do Not Retry, Call Issuer"},
+	ProcessorResponseCallIssuer:                          {ResponseCategorySoftDecline, "Declined - Call Issuer"},
+	ProcessorResponseInvalidMerchantNumber:               {ResponseCategoryHardDecline, "Invalid Merchant Number"},
+	ProcessorResponseDeclined:                            {ResponseCategorySoftDecline, "Declined"},
+	ProcessorResponsePickUpCard:                          {ResponseCategoryFraud, "Call Issuer. Pick Up Card"},
+	ProcessorResponseInvalidAmount:                       {ResponseCategoryHardDecline, "Invalid Amount"},
+	ProcessorResponseInvalidSKUNumber:                    {ResponseCategoryHardDecline, "Invalid SKU Number"},
+	ProcessorResponseInvalidCreditPlan:                   {ResponseCategoryHardDecline, "Invalid Credit Plan"},
+	ProcessorResponseCardNumberDoesNotMatchPaymentMethod: {ResponseCategoryHardDecline, "Credit Card Number does not match method of payment"},
+	ProcessorResponseCardReportedLostOrStolen:            {ResponseCategoryFraud, "Card reported as lost or stolen"},
+	ProcessorResponseReversalAmountMismatch:              {ResponseCategoryHardDecline, "Reversal amount does not match authorization amount"},
+	ProcessorResponseInvalidTransactionDivisionNumber:    {ResponseCategoryHardDecline, "Invalid Transaction Division Number"},
+	ProcessorResponseTransactionDivisionLimitExceeded:    {ResponseCategorySoftDecline, "Transaction amount exceeds the transaction division limit"},
+	ProcessorResponseIssuerRestriction:                   {ResponseCategoryHardDecline, "Issuer or Cardholder has put a restriction on the card"},
+	ProcessorResponseMerchantNotSecureCodeEnabled:        {ResponseCategoryHardDecline, "Merchant not Mastercard SecureCode enabled"},
+	ProcessorResponseAddressVerificationFailed:           {ResponseCategoryFraud, "Address Verification Failed"},
+	ProcessorResponseAddressAndCardSecurityCodeFailed:    {ResponseCategoryFraud, "Address Verification and Card Security Code Failed"},
+	ProcessorResponseInvalidTransactionData:              {ResponseCategoryHardDecline, "Invalid Transaction Data"},
+	ProcessorResponseInvalidTaxAmount:                    {ResponseCategoryHardDecline, "Invalid Tax Amount"},
+	ProcessorResponseInvalidCurrencyCode:                 {ResponseCategoryHardDecline, "Invalid Currency Code"},
+	ProcessorResponseRefundTimeLimitExceeded:             {ResponseCategoryHardDecline, "Refund Time Limit Exceeded"},
+	ProcessorResponseAuthorizationExpired:                {ResponseCategoryHardDecline, "Authorization Expired"},
+	ProcessorResponsePayPalFundingInstrumentDeclined:     {ResponseCategorySoftDecline, "Funding Instrument In The PayPal Account Was Declined"},
+	ProcessorResponsePayPalPayerAccountLockedOrClosed:    {ResponseCategoryHardDecline, "Payer Account Is Locked Or Closed"},
+	ProcessorResponsePayPalPayerCannotPay:                {ResponseCategoryHardDecline, "Payer Cannot Pay For This Transaction With PayPal"},
+	ProcessorResponsePayPalRiskModelRefused:              {ResponseCategoryFraud, "Transaction Refused Due To PayPal Risk Model"},
+	ProcessorResponsePayPalTransactionLimitExceeded:      {ResponseCategorySoftDecline, "PayPal Transaction Limit Exceeded"},
+	ProcessorResponseProcessorNetworkUnavailable:         {ResponseCategoryRetryable, "Processor Network Unavailable - Try Again"},
+}
+
+// Category returns the category of the code. Codes missing from the catalog are
+// classified by their range: 1000s are approvals, 2000s declines and 3000s failures
+// that can be retried.
+func (c ProcessorResponseCode) Category() ResponseCategory {
+	if info, ok := processorResponses[c]; ok {
+		return info.category
+	}
+	switch {
+	case c >= 1000 && c < 2000:
+		return ResponseCategoryApproved
+	case c >= 2000 && c < 3000:
+		return ResponseCategorySoftDecline
+	case c >= 3000 && c < 4000:
+		return ResponseCategoryRetryable
+	}
+	return ResponseCategoryUnknown
+}
+
+// Message returns the default human readable message for the code, or "" if the code
+// is not in the catalog.
+func (c ProcessorResponseCode) Message() string {
+	return processorResponses[c].message
+}
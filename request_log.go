@@ -0,0 +1,105 @@
+package braintree
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"io"
+	"time"
+)
+
+// DefaultRedactedElements are the XML elements whose content is always masked in logs,
+// they hold card data, social security and tax numbers and bank account details.
+var DefaultRedactedElements = []string{
+	"number",
+	"cvv",
+	"ssn",
+	"account-number",
+	"routing-number",
+	"tax-id",
+}
+
+const (
+	redactedMask      = "[FILTERED]"
+	redactedMalformed = "[FILTERED: malformed XML]"
+)
+
+// RequestLog describes a completed call to the gateway. Bodies are redacted.
+type RequestLog struct {
+	Method       string
+	Path         string
+	ApiVersion   ApiVersion
+	Status       int // 0 if no response was received
+	Attempts     int
+	Duration     time.Duration
+	RequestBody  []byte
+	ResponseBody []byte
+	Err          error
+}
+
+// RequestLogger receives structured log entries for calls to the gateway.
+type RequestLogger interface {
+	LogRequest(ctx context.Context, entry *RequestLog)
+}
+
+// RequestLoggerFunc adapts a function to the RequestLogger interface.
+type RequestLoggerFunc func(ctx context.Context, entry *RequestLog)
+
+func (f RequestLoggerFunc) LogRequest(ctx context.Context, entry *RequestLog) {
+	f(ctx, entry)
+}
+
+func (g *Braintree) redact(body []byte) []byte {
+	elements := make(map[string]bool, len(DefaultRedactedElements)+len(g.RedactElements))
+	for _, e := range DefaultRedactedElements {
+		elements[e] = true
+	}
+	for _, e := range g.RedactElements {
+		elements[e] = true
+	}
+	return redactXML(body, elements)
+}
+
+// redactXML masks the text content of the given elements, including anything nested
+// inside them, and leaves the rest of the document untouched. If the document can't
+// be parsed, everything from the point of failure on is masked.
+func redactXML(body []byte, elements map[string]bool) []byte {
+	var (
+		out   bytes.Buffer
+		dec   = xml.NewDecoder(bytes.NewReader(body))
+		last  int64
+		depth int
+	)
+	for {
+		start := dec.InputOffset()
+		tok, err := dec.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			out.Write(body[last:start])
+			out.WriteString(redactedMalformed)
+			return out.Bytes()
+		}
+		end := dec.InputOffset()
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if depth > 0 || elements[t.Name.Local] {
+				depth++
+			}
+		case xml.EndElement:
+			if depth > 0 {
+				depth--
+			}
+		case xml.CharData:
+			if depth > 0 && len(bytes.TrimSpace(t)) > 0 {
+				out.Write(body[last:start])
+				out.WriteString(redactedMask)
+				last = end
+			}
+		}
+	}
+	out.Write(body[last:])
+	return out.Bytes()
+}
@@ -0,0 +1,112 @@
+package braintree
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRedactXML(t *testing.T) {
+	t.Parallel()
+
+	g := New(Sandbox, "merchant-id", "public-key", "private-key")
+	g.RedactElements = []string{"date-of-birth"}
+
+	ma := &MerchantAccount{
+		Id: "sub-merchant",
+		Individual: &MerchantAccountPerson{
+			FirstName:   "Kayle",
+			DateOfBirth: "1-1-1989",
+			SSN:         "123-00-1234",
+		},
+		Business: &MerchantAccountBusiness{
+			LegalName: "Acme",
+			TaxId:     "98-7654321",
+		},
+		FundingOptions: &MerchantAccountFundingOptions{
+			Destination:   FUNDING_DEST_BANK,
+			AccountNumber: "43759348798",
+			RoutingNumber: "071101307",
+		},
+	}
+	b, err := xml.Marshal(ma)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	redacted := string(g.redact(b))
+	for _, secret := range []string{"1-1-1989", "123-00-1234", "98-7654321", "43759348798", "071101307"} {
+		if strings.Contains(redacted, secret) {
+			t.Errorf("%q was not redacted: %s", secret, redacted)
+		}
+	}
+	for _, visible := range []string{"<id>sub-merchant</id>", "<first-name>Kayle</first-name>", "<ssn>[FILTERED]</ssn>", "<legal-name>Acme</legal-name>"} {
+		if !strings.Contains(redacted, visible) {
+			t.Errorf("expected %q in %s", visible, redacted)
+		}
+	}
+}
+
+func TestRedactXMLNestedAndMalformed(t *testing.T) {
+	t.Parallel()
+
+	elements := map[string]bool{"credit-card": true}
+
+	got := string(redactXML([]byte(`<customer><id>1</id><credit-card><number>4111111111111111</number><cvv>123</cvv></credit-card></customer>`), elements))
+	want := `<customer><id>1</id><credit-card><number>[FILTERED]</number><cvv>[FILTERED]</cvv></credit-card></customer>`
+	if got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	got = string(redactXML([]byte(`<customer><id>1</id><credit-card><number>4111111111111111<`), elements))
+	if strings.Contains(got, "4111111111111111") {
+		t.Errorf("malformed XML was not redacted: %s", got)
+	}
+}
+
+func TestLoggerRedactsRequestAndResponse(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeXML(w, http.StatusCreated, `<credit-card><token>abc</token><number>4111111111111111</number></credit-card>`)
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	g := testServerGateway(srv)
+	g.Logger = log.New(&buf, "", 0)
+
+	var entries []*RequestLog
+	g.RequestLogger = RequestLoggerFunc(func(ctx context.Context, entry *RequestLog) {
+		entries = append(entries, entry)
+	})
+
+	_, err := g.CreditCard().Create(context.Background(), &CreditCard{
+		Number:         "4111111111111111",
+		CVV:            "100",
+		ExpirationDate: "05/14",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if out := buf.String(); strings.Contains(out, "4111111111111111") || strings.Contains(out, ">100<") {
+		t.Fatalf("log contains card data: %s", out)
+	}
+
+	if len(entries) != 1 {
+		t.Fatalf("got %d log entries, want 1", len(entries))
+	}
+	e := entries[0]
+	if e.Method != "POST" || e.Path != "payment_methods" || e.Status != http.StatusCreated || e.Attempts != 1 || e.Err != nil {
+		t.Fatalf("unexpected log entry %+v", e)
+	}
+	if bytes.Contains(e.RequestBody, []byte("4111111111111111")) || bytes.Contains(e.ResponseBody, []byte("4111111111111111")) {
+		t.Fatalf("log entry contains card data: %s %s", e.RequestBody, e.ResponseBody)
+	}
+}
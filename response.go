@@ -1,11 +1,15 @@
 package braintree
 
 import (
+	"bytes"
 	"compress/gzip"
 	"encoding/xml"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"strings"
 )
 
 type Response struct {
@@ -41,6 +45,22 @@ func (r *Response) transaction() (*Transaction, error) {
 	return &b, nil
 }
 
+func (r *Response) dispute() (*Dispute, error) {
+	var b Dispute
+	if err := xml.Unmarshal(r.Body, &b); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (r *Response) disputeEvidence() (*DisputeEvidence, error) {
+	var b DisputeEvidence
+	if err := xml.Unmarshal(r.Body, &b); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
 func (r *Response) paymentMethod() (PaymentMethod, error) {
 	entityName, err := r.entityName()
 	if err != nil {
@@ -113,6 +133,14 @@ func (r *Response) addOns() ([]AddOn, error) {
 	return b.AddOns, nil
 }
 
+func (r *Response) lineItems() ([]*TransactionLineItem, error) {
+	var b TransactionLineItems
+	if err := xml.Unmarshal(r.Body, &b); err != nil {
+		return nil, err
+	}
+	return b.LineItems, nil
+}
+
 func (r *Response) discounts() ([]Discount, error) {
 	var b DiscountList
 	if err := xml.Unmarshal(r.Body, &b); err != nil {
@@ -121,24 +149,97 @@ func (r *Response) discounts() ([]Discount, error) {
 	return b.Discounts, nil
 }
 
-func (r *Response) unpackBody() error {
-	if len(r.Body) == 0 {
-		b, err := gzip.NewReader(r.Response.Body)
+// DefaultMaxResponseBodySize is the largest response body read when
+// Braintree.MaxResponseBodySize is not set.
+const DefaultMaxResponseBodySize = 10 << 20
+
+// ErrResponseTooLarge is wrapped by the UnexpectedResponseError returned when a
+// response body exceeds the maximum body size.
+var ErrResponseTooLarge = errors.New("response body too large")
+
+// unpackBody reads and decodes the body according to its Content-Encoding and checks
+// that it is an XML document. At most maxSize bytes of decoded body are read. The
+// method and path of the request are reported in an UnexpectedResponseError.
+func (r *Response) unpackBody(method, path string, maxSize int64) error {
+	if len(r.Body) != 0 {
+		return nil
+	}
+	if maxSize <= 0 {
+		maxSize = DefaultMaxResponseBodySize
+	}
+	defer r.Response.Body.Close()
+
+	var body io.Reader = r.Response.Body
+	switch enc := strings.ToLower(strings.TrimSpace(r.Header.Get("Content-Encoding"))); enc {
+	case "gzip":
+		b, err := gzip.NewReader(body)
+		if err == io.EOF {
+			return nil
+		}
 		if err != nil {
-			return err
+			return r.unexpected(method, path, err)
 		}
-		defer r.Response.Body.Close()
+		defer b.Close()
+		body = b
+	case "", "identity":
+	default:
+		return r.unexpected(method, path, fmt.Errorf("unsupported content encoding %q", enc))
+	}
 
-		buf, err := ioutil.ReadAll(b)
+	buf, err := ioutil.ReadAll(io.LimitReader(body, maxSize+1))
+	if err != nil {
+		// A body cut short, e.g. a truncated gzip stream, keeps what was read as the snippet.
+		r.Body = buf
+		return r.unexpected(method, path, err)
+	}
+	if int64(len(buf)) > maxSize {
+		r.Body = buf[:maxSize]
+		return r.unexpected(method, path, ErrResponseTooLarge)
+	}
+	r.Body = buf
+
+	if len(bytes.TrimSpace(buf)) == 0 {
+		return nil
+	}
+	if ct := r.Header.Get("Content-Type"); ct != "" && !strings.Contains(ct, "xml") {
+		return r.unexpected(method, path, fmt.Errorf("unexpected content type %q", ct))
+	}
+	if err := checkXML(buf); err != nil {
+		return r.unexpected(method, path, err)
+	}
+	return nil
+}
+
+// checkXML returns an error if b is not a well-formed XML document.
+func checkXML(b []byte) error {
+	dec := xml.NewDecoder(bytes.NewReader(b))
+	for {
+		_, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		}
 		if err != nil {
 			return err
 		}
-		r.Body = buf
 	}
-	return nil
 }
 
-func (r *Response) apiError() error {
+func (r *Response) unexpected(method, path string, err error) *UnexpectedResponseError {
+	body := r.Body
+	if len(body) > unexpectedResponseSnippetSize {
+		body = body[:unexpectedResponseSnippetSize]
+	}
+	return &UnexpectedResponseError{
+		statusCode:  r.StatusCode,
+		Method:      method,
+		Path:        path,
+		ContentType: r.Header.Get("Content-Type"),
+		Body:        string(body),
+		Err:         err,
+	}
+}
+
+func (r *Response) apiError(method, path string) error {
 	var b BraintreeError
 	xml.Unmarshal(r.Body, &b)
 	if b.ErrorMessage != "" {
@@ -146,11 +247,70 @@ func (r *Response) apiError() error {
 		return &b
 	}
 	if r.StatusCode > 299 {
-		return fmt.Errorf("%s (%d)", http.StatusText(r.StatusCode), r.StatusCode)
+		return &HTTPError{
+			statusCode: r.StatusCode,
+			Method:     method,
+			Path:       path,
+		}
 	}
 	return nil
 }
 
+// Sentinel errors for HTTP level failures, use errors.Is to test for them.
+var (
+	ErrAuthentication     = errors.New("braintree: authentication failed")
+	ErrAuthorization      = errors.New("braintree: not authorized")
+	ErrNotFound           = errors.New("braintree: not found")
+	ErrUpgradeRequired    = errors.New("braintree: upgrade required")
+	ErrTooManyRequests    = errors.New("braintree: too many requests")
+	ErrServerError        = errors.New("braintree: server error")
+	ErrDownForMaintenance = errors.New("braintree: down for maintenance")
+)
+
+// statusError returns the sentinel error for the HTTP status code, or nil.
+func statusError(code int) error {
+	switch code {
+	case http.StatusUnauthorized:
+		return ErrAuthentication
+	case http.StatusForbidden:
+		return ErrAuthorization
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusUpgradeRequired:
+		return ErrUpgradeRequired
+	case http.StatusTooManyRequests:
+		return ErrTooManyRequests
+	case http.StatusInternalServerError:
+		return ErrServerError
+	case http.StatusServiceUnavailable:
+		return ErrDownForMaintenance
+	}
+	return nil
+}
+
+// HTTPError is returned when the gateway responds with an error status but without
+// an API error response. It matches the sentinel error for its status with errors.Is.
+// Some gateways synthesize it for a request that was never made, e.g. PlanGateway.Find
+// reports a missing plan as a 404 for "plans/"+id after listing all plans.
+type HTTPError struct {
+	statusCode int
+	Method     string
+	// Path is the request path relative to the merchant URL, e.g. "transactions/abc".
+	Path string
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("%s (%d)", http.StatusText(e.statusCode), e.statusCode)
+}
+
+func (e *HTTPError) StatusCode() int {
+	return e.statusCode
+}
+
+func (e *HTTPError) Unwrap() error {
+	return statusError(e.statusCode)
+}
+
 type APIError interface {
 	error
 	StatusCode() int
@@ -172,3 +332,38 @@ func (e *invalidResponseError) Error() string {
 func (e *invalidResponseError) Response() *Response {
 	return e.resp
 }
+
+const unexpectedResponseSnippetSize = 512
+
+// UnexpectedResponseError is returned when the gateway, or something in between such
+// as a proxy or load balancer, responds with a body that isn't a Braintree XML
+// document, e.g. an HTML error page.
+type UnexpectedResponseError struct {
+	statusCode int
+	Method     string
+	// Path is the request path relative to the merchant URL, e.g. "transactions/abc".
+	Path        string
+	ContentType string
+	// Body holds the beginning of the response body.
+	Body string
+	// Err is the reason the body was rejected.
+	Err error
+}
+
+func (e *UnexpectedResponseError) Error() string {
+	return fmt.Sprintf("braintree returned unexpected response (%d, %q): %s", e.statusCode, e.ContentType, e.Err)
+}
+
+func (e *UnexpectedResponseError) StatusCode() int {
+	return e.statusCode
+}
+
+func (e *UnexpectedResponseError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is the sentinel error for the response status,
+// e.g. an HTML error page served with a 503 matches ErrDownForMaintenance.
+func (e *UnexpectedResponseError) Is(target error) bool {
+	return target != nil && target == statusError(e.statusCode)
+}
@@ -0,0 +1,79 @@
+package braintree
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+func TestProcessorResponseCodeCategory(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		code ProcessorResponseCode
+		want ResponseCategory
+	}{
+		{ProcessorResponseApproved, ResponseCategoryApproved},
+		{ProcessorResponseInsufficientFunds, ResponseCategorySoftDecline},
+		{ProcessorResponseExpiredCard, ResponseCategoryHardDecline},
+		{ProcessorResponseCardIssuerDeclinedCVV, ResponseCategoryFraud},
+		{ProcessorResponseProcessorNetworkUnavailable, ResponseCategoryRetryable},
+		{1099, ResponseCategoryApproved},
+		{2999, ResponseCategorySoftDecline},
+		{3999, ResponseCategoryRetryable},
+		{0, ResponseCategoryUnknown},
+	}
+	for _, tt := range tests {
+		if got := tt.code.Category(); got != tt.want {
+			t.Errorf("%d: got %q, want %q", tt.code, got, tt.want)
+		}
+	}
+
+	if msg := ProcessorResponseInsufficientFunds.Message(); msg != "Insufficient Funds" {
+		t.Errorf("got message %q", msg)
+	}
+	if msg := ProcessorResponseCode(2999).Message(); msg != "" {
+		t.Errorf("got message %q for an unknown code", msg)
+	}
+}
+
+func TestTransactionResponseCategory(t *testing.T) {
+	t.Parallel()
+
+	tx := &Transaction{}
+	if err := xml.Unmarshal([]byte(`<transaction><processor-response-code>2010</processor-response-code></transaction>`), tx); err != nil {
+		t.Fatal(err)
+	}
+	if tx.ProcessorResponseCode != ProcessorResponseCardIssuerDeclinedCVV {
+		t.Fatalf("got %d", tx.ProcessorResponseCode)
+	}
+	if c := tx.ResponseCategory(); c != ResponseCategoryFraud {
+		t.Fatalf("got %q", c)
+	}
+}
+
+func TestFieldErrorCategory(t *testing.T) {
+	t.Parallel()
+
+	apiErrors := &BraintreeError{}
+	if err := xml.Unmarshal(customerErrorXML, apiErrors); err != nil {
+		t.Fatal(err)
+	}
+
+	cvvErrors := apiErrors.For("Customer").For("CreditCard").On("CVV")
+	if len(cvvErrors) != 1 || cvvErrors[0].Code != ErrorCodeCreditCardCVVIsInvalid {
+		t.Fatalf("got %+v", cvvErrors)
+	}
+	if c := cvvErrors[0].Category(); c != ResponseCategoryValidation {
+		t.Fatalf("got %q", c)
+	}
+
+	if c := ErrorCodeCreditCardCVVVerificationFailed.Category(); c != ResponseCategoryFraud {
+		t.Fatalf("got %q", c)
+	}
+	if c := ValidationErrorCode("99999").Category(); c != ResponseCategoryValidation {
+		t.Fatalf("got %q for an unknown code", c)
+	}
+	if msg := ErrorCodeTransactionAmountIsRequired.Message(); msg != "Amount is required." {
+		t.Fatalf("got message %q", msg)
+	}
+}
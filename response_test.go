@@ -0,0 +1,182 @@
+package braintree
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newTestResponse(status int, header http.Header, body []byte) *Response {
+	return &Response{
+		Response: &http.Response{
+			StatusCode: status,
+			Header:     header,
+			Body:       ioutil.NopCloser(bytes.NewReader(body)),
+		},
+	}
+}
+
+func gzipBytes(b []byte) []byte {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write(b)
+	zw.Close()
+	return buf.Bytes()
+}
+
+func TestUnpackBody(t *testing.T) {
+	t.Parallel()
+
+	xmlBody := []byte(`<?xml version="1.0" encoding="UTF-8"?><transaction><id>abc</id></transaction>`)
+
+	tests := []struct {
+		name   string
+		header http.Header
+		body   []byte
+	}{
+		{"gzip", http.Header{"Content-Encoding": {"gzip"}, "Content-Type": {"application/xml; charset=utf-8"}}, gzipBytes(xmlBody)},
+		{"plain", http.Header{"Content-Type": {"application/xml"}}, xmlBody},
+		{"identity", http.Header{"Content-Encoding": {"identity"}}, xmlBody},
+	}
+	for _, tt := range tests {
+		r := newTestResponse(200, tt.header, tt.body)
+		if err := r.unpackBody("GET", "transactions/abc", 0); err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			continue
+		}
+		if !bytes.Equal(r.Body, xmlBody) {
+			t.Errorf("%s: got body %q", tt.name, r.Body)
+		}
+	}
+}
+
+func TestUnpackBodyEmpty(t *testing.T) {
+	t.Parallel()
+
+	for _, body := range [][]byte{nil, gzipBytes(nil)} {
+		r := newTestResponse(200, http.Header{"Content-Encoding": {"gzip"}}, body)
+		if err := r.unpackBody("GET", "transactions/abc", 0); err != nil {
+			t.Errorf("%q: %s", body, err)
+		}
+	}
+}
+
+func TestUnpackBodyUnexpected(t *testing.T) {
+	t.Parallel()
+
+	html := []byte("<html><body><h1>502 Bad Gateway</h1>" + strings.Repeat("x", 1000) + "</body></html>")
+	gzipped := gzipBytes([]byte("<transaction><id>" + strings.Repeat("a", 1000) + "</id></transaction>"))
+
+	tests := []struct {
+		name   string
+		status int
+		header http.Header
+		body   []byte
+	}{
+		{"html error page", 502, http.Header{"Content-Type": {"text/html"}}, html},
+		{"truncated xml", 200, http.Header{"Content-Type": {"application/xml"}}, []byte("<transaction><id>abc</id>")},
+		{"bad gzip", 500, http.Header{"Content-Encoding": {"gzip"}}, []byte("not gzipped")},
+		{"unknown encoding", 200, http.Header{"Content-Encoding": {"br"}}, []byte("whatever")},
+		{"truncated gzip", 502, http.Header{"Content-Encoding": {"gzip"}, "Content-Type": {"application/xml"}}, gzipped[:len(gzipped)/2]},
+	}
+	for _, tt := range tests {
+		r := newTestResponse(tt.status, tt.header, tt.body)
+		err := r.unpackBody("GET", "transactions/abc", 0)
+		var unexpected *UnexpectedResponseError
+		if !errors.As(err, &unexpected) {
+			t.Errorf("%s: got %#v, want an *UnexpectedResponseError", tt.name, err)
+			continue
+		}
+		if unexpected.StatusCode() != tt.status {
+			t.Errorf("%s: got status %d, want %d", tt.name, unexpected.StatusCode(), tt.status)
+		}
+		if unexpected.Method != "GET" || unexpected.Path != "transactions/abc" {
+			t.Errorf("%s: got request %s %s", tt.name, unexpected.Method, unexpected.Path)
+		}
+		if unexpected.ContentType != tt.header.Get("Content-Type") {
+			t.Errorf("%s: got content type %q", tt.name, unexpected.ContentType)
+		}
+		if len(unexpected.Body) > unexpectedResponseSnippetSize {
+			t.Errorf("%s: body snippet is %d bytes long", tt.name, len(unexpected.Body))
+		}
+	}
+}
+
+func TestUnpackBodyTooLarge(t *testing.T) {
+	t.Parallel()
+
+	body := []byte("<transaction><id>" + strings.Repeat("a", 100) + "</id></transaction>")
+	r := newTestResponse(200, http.Header{"Content-Encoding": {"gzip"}}, gzipBytes(body))
+
+	err := r.unpackBody("GET", "transactions/abc", 50)
+	if !errors.Is(err, ErrResponseTooLarge) {
+		t.Fatalf("got %#v, want ErrResponseTooLarge", err)
+	}
+}
+
+func TestHTTPErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		status int
+		want   error
+	}{
+		{http.StatusUnauthorized, ErrAuthentication},
+		{http.StatusForbidden, ErrAuthorization},
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusUpgradeRequired, ErrUpgradeRequired},
+		{http.StatusTooManyRequests, ErrTooManyRequests},
+		{http.StatusInternalServerError, ErrServerError},
+		{http.StatusServiceUnavailable, ErrDownForMaintenance},
+	}
+	for _, tt := range tests {
+		status := tt.status
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			writeXML(w, status, "")
+		}))
+		g := testServerGateway(srv)
+
+		_, err := g.Customer().Find(context.Background(), "cust-1")
+		srv.Close()
+
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%d: got %#v, want %#v", tt.status, err, tt.want)
+			continue
+		}
+		var httpErr *HTTPError
+		if !errors.As(err, &httpErr) {
+			t.Errorf("%d: got %#v, want an *HTTPError", tt.status, err)
+			continue
+		}
+		if httpErr.StatusCode() != tt.status || httpErr.Method != "GET" || httpErr.Path != "customers/cust-1" {
+			t.Errorf("%d: unexpected error %+v", tt.status, httpErr)
+		}
+	}
+}
+
+func TestHTTPErrorFromHTMLPage(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte("<html><body>Down for maintenance</body></html>"))
+	}))
+	defer srv.Close()
+
+	g := testServerGateway(srv)
+
+	_, err := g.Transaction().Find(context.Background(), "abc123")
+	if !errors.Is(err, ErrDownForMaintenance) {
+		t.Fatalf("got %#v, want ErrDownForMaintenance", err)
+	}
+	if errors.Is(err, ErrNotFound) {
+		t.Fatal("did not expect error to match ErrNotFound")
+	}
+}
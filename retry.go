@@ -0,0 +1,135 @@
+package braintree
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy configures how requests that fail for transient reasons are retried.
+// Network errors and 429, 502, 503 and 504 responses are considered transient.
+//
+// Only idempotent requests (GET, DELETE and searches) are retried, unless
+// RetryMutating is set, in which case POST and PUT requests whose payload carries
+// an idempotency key (see IdempotencyKeyer) are retried as well.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry, it doubles on every retry after that.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between two attempts, including delays requested by Retry-After.
+	MaxDelay time.Duration
+	// RetryMutating enables retries of POST and PUT requests that carry an idempotency key.
+	RetryMutating bool
+}
+
+// DefaultRetryPolicy returns a policy making up to 3 attempts, starting with a 500ms backoff.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    10 * time.Second,
+	}
+}
+
+// IdempotencyKeyer is implemented by request payloads that carry a key identifying
+// the operation on the caller's side, e.g. Transaction returns its OrderId.
+// Braintree does not deduplicate on this key itself, returning a non-empty key
+// asserts that the caller is able to reconcile a request that was executed twice.
+type IdempotencyKeyer interface {
+	IdempotencyKey() string
+}
+
+func (p *RetryPolicy) attempts() int {
+	if p == nil || p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// canRetry reports whether the request may be sent more than once.
+func (p *RetryPolicy) canRetry(method, path string, xmlObj interface{}) bool {
+	if p.attempts() < 2 {
+		return false
+	}
+	switch method {
+	case "GET", "DELETE":
+		return true
+	}
+	if strings.HasSuffix(path, "/advanced_search") || strings.HasSuffix(path, "/advanced_search_ids") {
+		return true
+	}
+	if p.RetryMutating {
+		if k, ok := xmlObj.(IdempotencyKeyer); ok && k.IdempotencyKey() != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func isRetryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// delay returns how long to wait before the given retry (starting at 1). A Retry-After
+// header takes precedence over the exponential backoff, both are capped at MaxDelay.
+func (p *RetryPolicy) delay(retry int, header http.Header) time.Duration {
+	if d, ok := retryAfter(header); ok {
+		return p.capDelay(d)
+	}
+	d := p.BaseDelay
+	for i := 1; i < retry && (p.MaxDelay <= 0 || d < p.MaxDelay); i++ {
+		d *= 2
+	}
+	d = p.capDelay(d)
+	if d <= 0 {
+		return 0
+	}
+	// Equal jitter, waits somewhere between half and the full backoff.
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+func (p *RetryPolicy) capDelay(d time.Duration) time.Duration {
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return d
+}
+
+// retryAfter parses the Retry-After header, which is either a number of seconds or an HTTP date.
+func retryAfter(header http.Header) (time.Duration, bool) {
+	v := header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// sleep waits for d, returning early with ctx.Err() if ctx is done first.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,209 @@
+package braintree
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testTransactionXML = `<transaction><id>abc123</id><status>authorized</status></transaction>`
+
+// scriptedServer responds with the given status codes in order, and with a
+// transaction once the script is exhausted.
+func scriptedServer(calls *int32, statuses ...int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(calls, 1))
+		if n <= len(statuses) {
+			w.WriteHeader(statuses[n-1])
+			return
+		}
+		status := http.StatusOK
+		if r.Method == "POST" {
+			status = http.StatusCreated
+		}
+		writeXML(w, status, testTransactionXML)
+	}))
+}
+
+func testRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    5 * time.Millisecond,
+	}
+}
+
+func TestRetryIdempotentRequest(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	srv := scriptedServer(&calls, http.StatusServiceUnavailable, http.StatusBadGateway)
+	defer srv.Close()
+
+	g := testServerGateway(srv)
+	g.RetryPolicy = testRetryPolicy()
+
+	tx, err := g.Transaction().Find(context.Background(), "abc123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Id != "abc123" {
+		t.Fatalf("got id %q", tx.Id)
+	}
+	if calls != 3 {
+		t.Fatalf("got %d calls, want 3", calls)
+	}
+}
+
+func TestRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	srv := scriptedServer(&calls, http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusTooManyRequests)
+	defer srv.Close()
+
+	g := testServerGateway(srv)
+	g.RetryPolicy = testRetryPolicy()
+
+	_, err := g.Transaction().Find(context.Background(), "abc123")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if calls != 3 {
+		t.Fatalf("got %d calls, want 3", calls)
+	}
+}
+
+func TestRetryDisabledByDefault(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	srv := scriptedServer(&calls, http.StatusServiceUnavailable)
+	defer srv.Close()
+
+	g := testServerGateway(srv)
+
+	_, err := g.Transaction().Find(context.Background(), "abc123")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if calls != 1 {
+		t.Fatalf("got %d calls, want 1", calls)
+	}
+}
+
+func TestRetryMutatingRequest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		retryMutating bool
+		orderId       string
+		wantCalls     int32
+	}{
+		{"not opted in", false, "order-1", 1},
+		{"without idempotency key", true, "", 1},
+		{"with idempotency key", true, "order-1", 2},
+	}
+
+	for _, tt := range tests {
+		var calls int32
+		srv := scriptedServer(&calls, http.StatusServiceUnavailable)
+
+		g := testServerGateway(srv)
+		g.RetryPolicy = testRetryPolicy()
+		g.RetryPolicy.RetryMutating = tt.retryMutating
+
+		_, err := g.Transaction().Create(context.Background(), &Transaction{
+			Type:    "sale",
+			Amount:  NewDecimal(1000, 2),
+			OrderId: tt.orderId,
+		})
+		srv.Close()
+
+		if tt.wantCalls == 1 && err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+		if tt.wantCalls > 1 && err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+		}
+		if calls != tt.wantCalls {
+			t.Errorf("%s: got %d calls, want %d", tt.name, calls, tt.wantCalls)
+		}
+	}
+}
+
+func TestRetrySearchRequest(t *testing.T) {
+	t.Parallel()
+
+	p := testRetryPolicy()
+	if !p.canRetry("POST", "transactions/advanced_search", new(SearchQuery)) {
+		t.Fatal("expected searches to be retryable")
+	}
+	if p.canRetry("POST", "transactions", &Transaction{}) {
+		t.Fatal("expected creates to not be retryable")
+	}
+}
+
+func TestRetryStopsWhenContextIsDone(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	srv := scriptedServer(&calls, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+	defer srv.Close()
+
+	g := testServerGateway(srv)
+	g.RetryPolicy = &RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Hour,
+		MaxDelay:    time.Hour,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := g.Transaction().Find(ctx, "abc123")
+	if err != context.DeadlineExceeded {
+		t.Fatalf("got %#v, want %#v", err, context.DeadlineExceeded)
+	}
+	if calls != 1 {
+		t.Fatalf("got %d calls, want 1", calls)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	t.Parallel()
+
+	p := &RetryPolicy{
+		MaxAttempts: 5,
+		BaseDelay:   100 * time.Millisecond,
+		MaxDelay:    time.Second,
+	}
+
+	for retry, max := range map[int]time.Duration{
+		1: 100 * time.Millisecond,
+		2: 200 * time.Millisecond,
+		3: 400 * time.Millisecond,
+		5: time.Second,
+		9: time.Second,
+	} {
+		d := p.delay(retry, nil)
+		if d < max/2 || d > max {
+			t.Errorf("retry %d: got delay %s, want between %s and %s", retry, d, max/2, max)
+		}
+	}
+
+	h := http.Header{}
+	h.Set("Retry-After", "0")
+	if d := p.delay(1, h); d != 0 {
+		t.Errorf("got delay %s with Retry-After: 0", d)
+	}
+
+	h.Set("Retry-After", "120")
+	if d := p.delay(1, h); d != time.Second {
+		t.Errorf("got delay %s, want Retry-After capped at %s", d, time.Second)
+	}
+}
@@ -2,7 +2,11 @@ package braintree
 
 import (
 	"encoding/xml"
+	"fmt"
+	"strconv"
 	"time"
+
+	"github.com/lionelbarrow/braintree-go/date"
 )
 
 type SearchQuery struct {
@@ -27,6 +31,10 @@ type TextField struct {
 	Contains   string `xml:"contains,omitempty"`
 }
 
+func (f *TextField) empty() bool {
+	return f.Is == "" && f.IsNot == "" && f.StartsWith == "" && f.EndsWith == "" && f.Contains == ""
+}
+
 type RangeField struct {
 	XMLName xml.Name
 	Is      float64 `xml:"is,omitempty"`
@@ -41,6 +49,10 @@ type TimeField struct {
 	Max     time.Time
 }
 
+func (d *TimeField) empty() bool {
+	return d.Is.IsZero() && d.Min.IsZero() && d.Max.IsZero()
+}
+
 func (d TimeField) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	start = start.Copy()
 	start.Name = d.XMLName
@@ -86,6 +98,10 @@ type MultiField struct {
 	Items   []string `xml:"item"`
 }
 
+func (f *MultiField) empty() bool {
+	return len(f.Items) == 0
+}
+
 func (s *SearchQuery) AddTextField(field string) *TextField {
 	f := &TextField{XMLName: xml.Name{Local: field}}
 	s.Fields = append(s.Fields, f)
@@ -112,3 +128,426 @@ func (s *SearchQuery) AddMultiField(field string) *MultiField {
 	s.Fields = append(s.Fields, f)
 	return f
 }
+
+// DecimalRangeField is like RangeField, except that only bounds that are nil are
+// omitted, so a bound of zero can be searched for.
+type DecimalRangeField struct {
+	XMLName xml.Name
+	Is      *Decimal `xml:"is,omitempty"`
+	Min     *Decimal `xml:"min,omitempty"`
+	Max     *Decimal `xml:"max,omitempty"`
+}
+
+func (f *DecimalRangeField) empty() bool {
+	return f.Is == nil && f.Min == nil && f.Max == nil
+}
+
+func (s *SearchQuery) AddDecimalRangeField(field string) *DecimalRangeField {
+	f := &DecimalRangeField{XMLName: xml.Name{Local: field}}
+	s.Fields = append(s.Fields, f)
+	return f
+}
+
+// IntRangeField is like RangeField for integer fields, only omitting bounds that are nil.
+type IntRangeField struct {
+	XMLName xml.Name
+	Is      *int `xml:"is,omitempty"`
+	Min     *int `xml:"min,omitempty"`
+	Max     *int `xml:"max,omitempty"`
+}
+
+func (f *IntRangeField) empty() bool {
+	return f.Is == nil && f.Min == nil && f.Max == nil
+}
+
+func (s *SearchQuery) AddIntRangeField(field string) *IntRangeField {
+	f := &IntRangeField{XMLName: xml.Name{Local: field}}
+	s.Fields = append(s.Fields, f)
+	return f
+}
+
+// DateRangeField is like TimeField for fields holding a date without a time of day.
+type DateRangeField struct {
+	XMLName xml.Name
+	Is      *date.Date
+	Min     *date.Date
+	Max     *date.Date
+}
+
+func (d *DateRangeField) empty() bool {
+	return d.Is == nil && d.Min == nil && d.Max == nil
+}
+
+func (d DateRangeField) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start = start.Copy()
+	start.Name = d.XMLName
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	criteria := []struct {
+		name  string
+		value *date.Date
+	}{{"is", d.Is}, {"min", d.Min}, {"max", d.Max}}
+	for _, c := range criteria {
+		if c.value == nil {
+			continue
+		}
+		start := xml.StartElement{Name: xml.Name{Local: c.name}}
+		start.Attr = []xml.Attr{{Name: xml.Name{Local: "type"}, Value: "date"}}
+		if err := e.EncodeElement(c.value, start); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+func (s *SearchQuery) AddDateRangeField(field string) *DateRangeField {
+	f := &DateRangeField{XMLName: xml.Name{Local: field}}
+	s.Fields = append(s.Fields, f)
+	return f
+}
+
+// KeyValueField matches a field against a single value, e.g. <refund>true</refund>.
+type KeyValueField struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
+
+func (f *KeyValueField) empty() bool {
+	return f.Value == ""
+}
+
+func (s *SearchQuery) AddKeyValueField(field string) *KeyValueField {
+	f := &KeyValueField{XMLName: xml.Name{Local: field}}
+	s.Fields = append(s.Fields, f)
+	return f
+}
+
+// searchCriteria holds the criteria of a typed search, creating each field of the
+// query the first time its criterion is used.
+type searchCriteria struct {
+	query    SearchQuery
+	criteria map[string]interface{}
+}
+
+// Query returns the search query built from the criteria, leaving out the fields
+// that have no value set.
+func (c *searchCriteria) Query() *SearchQuery {
+	q := &SearchQuery{}
+	for _, f := range c.query.Fields {
+		if f, ok := f.(interface{ empty() bool }); ok && f.empty() {
+			continue
+		}
+		q.Fields = append(q.Fields, f)
+	}
+	return q
+}
+
+func (c *searchCriteria) criterion(field string, create func() interface{}) interface{} {
+	if cr, ok := c.criteria[field]; ok {
+		return cr
+	}
+	if c.criteria == nil {
+		c.criteria = make(map[string]interface{})
+	}
+	cr := create()
+	c.criteria[field] = cr
+	return cr
+}
+
+func (c *searchCriteria) text(field string) *TextCriterion {
+	return c.criterion(field, func() interface{} {
+		return &TextCriterion{c.query.AddTextField(field)}
+	}).(*TextCriterion)
+}
+
+// customField returns the criterion of a custom field. Custom fields are kept apart
+// from the built-in fields, so a custom field named like one of them is a separate
+// criterion. Invalid names make the query fail to encode.
+func (c *searchCriteria) customField(name string) *TextCriterion {
+	return c.criterion("custom-field:"+name, func() interface{} {
+		if !validXMLName(name) {
+			c.query.Fields = append(c.query.Fields, invalidSearchField{name})
+			return &TextCriterion{&TextField{XMLName: xml.Name{Local: name}}}
+		}
+		return &TextCriterion{c.query.AddTextField(name)}
+	}).(*TextCriterion)
+}
+
+// invalidSearchField stands in for a criterion on a field that cannot be encoded.
+type invalidSearchField struct {
+	name string
+}
+
+func (f invalidSearchField) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return fmt.Errorf("invalid search field name %q", f.name)
+}
+
+func (c *searchCriteria) equality(field string) *EqualityCriterion {
+	return c.criterion(field, func() interface{} {
+		return &EqualityCriterion{c.query.AddTextField(field)}
+	}).(*EqualityCriterion)
+}
+
+func (c *searchCriteria) exactMatch(field string) *ExactMatchCriterion {
+	return c.criterion(field, func() interface{} {
+		return &ExactMatchCriterion{c.query.AddTextField(field)}
+	}).(*ExactMatchCriterion)
+}
+
+func (c *searchCriteria) partialMatch(field string) *PartialMatchCriterion {
+	return c.criterion(field, func() interface{} {
+		return &PartialMatchCriterion{c.query.AddTextField(field)}
+	}).(*PartialMatchCriterion)
+}
+
+func (c *searchCriteria) multipleValue(field string) *MultipleValueCriterion {
+	return c.criterion(field, func() interface{} {
+		return &MultipleValueCriterion{c.query.AddMultiField(field)}
+	}).(*MultipleValueCriterion)
+}
+
+func (c *searchCriteria) decimalRange(field string) *DecimalRangeCriterion {
+	return c.criterion(field, func() interface{} {
+		return &DecimalRangeCriterion{c.query.AddDecimalRangeField(field)}
+	}).(*DecimalRangeCriterion)
+}
+
+func (c *searchCriteria) intRange(field string) *IntRangeCriterion {
+	return c.criterion(field, func() interface{} {
+		return &IntRangeCriterion{c.query.AddIntRangeField(field)}
+	}).(*IntRangeCriterion)
+}
+
+func (c *searchCriteria) dateRange(field string) *DateRangeCriterion {
+	return c.criterion(field, func() interface{} {
+		return &DateRangeCriterion{c.query.AddDateRangeField(field)}
+	}).(*DateRangeCriterion)
+}
+
+func (c *searchCriteria) boolean(field string) *BoolCriterion {
+	return c.criterion(field, func() interface{} {
+		return &BoolCriterion{c.query.AddMultiField(field)}
+	}).(*BoolCriterion)
+}
+
+func (c *searchCriteria) timeRange(field string) *TimeRangeCriterion {
+	return c.criterion(field, func() interface{} {
+		return &TimeRangeCriterion{c.query.AddTimeField(field)}
+	}).(*TimeRangeCriterion)
+}
+
+func (c *searchCriteria) keyValue(field string) *KeyValueCriterion {
+	return c.criterion(field, func() interface{} {
+		return &KeyValueCriterion{c.query.AddKeyValueField(field)}
+	}).(*KeyValueCriterion)
+}
+
+// TextCriterion matches a text field with any of the text operators.
+type TextCriterion struct {
+	f *TextField
+}
+
+func (c *TextCriterion) Is(v string) *TextCriterion {
+	c.f.Is = v
+	return c
+}
+
+func (c *TextCriterion) IsNot(v string) *TextCriterion {
+	c.f.IsNot = v
+	return c
+}
+
+func (c *TextCriterion) StartsWith(v string) *TextCriterion {
+	c.f.StartsWith = v
+	return c
+}
+
+func (c *TextCriterion) EndsWith(v string) *TextCriterion {
+	c.f.EndsWith = v
+	return c
+}
+
+func (c *TextCriterion) Contains(v string) *TextCriterion {
+	c.f.Contains = v
+	return c
+}
+
+// EqualityCriterion matches a text field that only supports exact matches.
+type EqualityCriterion struct {
+	f *TextField
+}
+
+func (c *EqualityCriterion) Is(v string) *EqualityCriterion {
+	c.f.Is = v
+	return c
+}
+
+func (c *EqualityCriterion) IsNot(v string) *EqualityCriterion {
+	c.f.IsNot = v
+	return c
+}
+
+// ExactMatchCriterion matches a text field that only supports the is operator.
+type ExactMatchCriterion struct {
+	f *TextField
+}
+
+func (c *ExactMatchCriterion) Is(v string) *ExactMatchCriterion {
+	c.f.Is = v
+	return c
+}
+
+// PartialMatchCriterion matches a text field by its start or end, but not by
+// what it contains, e.g. a credit card number.
+type PartialMatchCriterion struct {
+	f *TextField
+}
+
+func (c *PartialMatchCriterion) Is(v string) *PartialMatchCriterion {
+	c.f.Is = v
+	return c
+}
+
+func (c *PartialMatchCriterion) IsNot(v string) *PartialMatchCriterion {
+	c.f.IsNot = v
+	return c
+}
+
+func (c *PartialMatchCriterion) StartsWith(v string) *PartialMatchCriterion {
+	c.f.StartsWith = v
+	return c
+}
+
+func (c *PartialMatchCriterion) EndsWith(v string) *PartialMatchCriterion {
+	c.f.EndsWith = v
+	return c
+}
+
+// MultipleValueCriterion matches a field against a set of values.
+type MultipleValueCriterion struct {
+	f *MultiField
+}
+
+// In adds values to the set of values matched.
+func (c *MultipleValueCriterion) In(values ...string) *MultipleValueCriterion {
+	c.f.Items = append(c.f.Items, values...)
+	return c
+}
+
+// DecimalRangeCriterion matches a decimal field, e.g. an amount. Bounds are inclusive.
+type DecimalRangeCriterion struct {
+	f *DecimalRangeField
+}
+
+func (c *DecimalRangeCriterion) Is(v *Decimal) *DecimalRangeCriterion {
+	c.f.Is = v
+	return c
+}
+
+func (c *DecimalRangeCriterion) AtLeast(min *Decimal) *DecimalRangeCriterion {
+	c.f.Min = min
+	return c
+}
+
+func (c *DecimalRangeCriterion) AtMost(max *Decimal) *DecimalRangeCriterion {
+	c.f.Max = max
+	return c
+}
+
+func (c *DecimalRangeCriterion) Between(min, max *Decimal) *DecimalRangeCriterion {
+	c.f.Min, c.f.Max = min, max
+	return c
+}
+
+// IntRangeCriterion matches an integer field. Bounds are inclusive.
+type IntRangeCriterion struct {
+	f *IntRangeField
+}
+
+func (c *IntRangeCriterion) Is(v int) *IntRangeCriterion {
+	c.f.Is = &v
+	return c
+}
+
+func (c *IntRangeCriterion) AtLeast(min int) *IntRangeCriterion {
+	c.f.Min = &min
+	return c
+}
+
+func (c *IntRangeCriterion) AtMost(max int) *IntRangeCriterion {
+	c.f.Max = &max
+	return c
+}
+
+func (c *IntRangeCriterion) Between(min, max int) *IntRangeCriterion {
+	c.f.Min, c.f.Max = &min, &max
+	return c
+}
+
+// DateRangeCriterion matches a date field. Bounds are inclusive and only the date
+// of the times passed is used.
+type DateRangeCriterion struct {
+	f *DateRangeField
+}
+
+func (c *DateRangeCriterion) Is(v time.Time) *DateRangeCriterion {
+	c.f.Is = &date.Date{Time: v}
+	return c
+}
+
+func (c *DateRangeCriterion) AtLeast(min time.Time) *DateRangeCriterion {
+	c.f.Min = &date.Date{Time: min}
+	return c
+}
+
+func (c *DateRangeCriterion) AtMost(max time.Time) *DateRangeCriterion {
+	c.f.Max = &date.Date{Time: max}
+	return c
+}
+
+func (c *DateRangeCriterion) Between(min, max time.Time) *DateRangeCriterion {
+	c.f.Min, c.f.Max = &date.Date{Time: min}, &date.Date{Time: max}
+	return c
+}
+
+// TimeRangeCriterion matches a time field. Bounds are inclusive.
+type TimeRangeCriterion struct {
+	f *TimeField
+}
+
+func (c *TimeRangeCriterion) AtLeast(min time.Time) *TimeRangeCriterion {
+	c.f.Min = min
+	return c
+}
+
+func (c *TimeRangeCriterion) AtMost(max time.Time) *TimeRangeCriterion {
+	c.f.Max = max
+	return c
+}
+
+func (c *TimeRangeCriterion) Between(min, max time.Time) *TimeRangeCriterion {
+	c.f.Min, c.f.Max = min, max
+	return c
+}
+
+// KeyValueCriterion matches a field that is either true or false.
+type KeyValueCriterion struct {
+	f *KeyValueField
+}
+
+func (c *KeyValueCriterion) Is(v bool) *KeyValueCriterion {
+	c.f.Value = strconv.FormatBool(v)
+	return c
+}
+
+// BoolCriterion matches a field that is either true or false, sent as a set of values.
+type BoolCriterion struct {
+	f *MultiField
+}
+
+func (c *BoolCriterion) Is(v bool) *BoolCriterion {
+	c.f.Items = []string{strconv.FormatBool(v)}
+	return c
+}
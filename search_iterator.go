@@ -0,0 +1,197 @@
+package braintree
+
+import (
+	"context"
+	"encoding/xml"
+	"strconv"
+)
+
+// searchPageFunc fetches the entities with the given ids.
+type searchPageFunc func(ctx context.Context, ids []string) ([]interface{}, error)
+
+type searchPage struct {
+	items []interface{}
+	err   error
+}
+
+// SearchIterator walks the results of a search the way Braintree intends searches to
+// be paged: the ids of all matching entities are fetched up front, then the entities
+// themselves are fetched in chunks of the page size returned by the gateway.
+//
+// Iterators are not safe for concurrent use. Call Close when stopping before the end
+// of the results so that pages being fetched ahead are abandoned.
+type SearchIterator struct {
+	// Concurrency is the maximum number of pages fetched at the same time. Pages are
+	// still returned in order. It must be set before the first call to Next and
+	// defaults to 1, which fetches each page only once the previous one is consumed.
+	Concurrency int
+
+	ctx      context.Context
+	cancel   context.CancelFunc
+	fetch    searchPageFunc
+	ids      []string
+	pageSize int
+
+	pages <-chan chan searchPage
+	items []interface{}
+	item  interface{}
+	err   error
+	done  bool
+}
+
+// newSearchIterator runs the first phase of a search for the named gateway, fetching
+// the ids of all entities matching query from path/advanced_search_ids.
+func newSearchIterator(ctx context.Context, g *Braintree, gateway, path string, query *SearchQuery, fetch searchPageFunc) (*SearchIterator, error) {
+	resp, err := g.execute(ctx, gateway, "POST", path+"/advanced_search_ids", query)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case 200:
+	default:
+		return nil, &invalidResponseError{resp}
+	}
+	var v SearchResults
+	if err := xml.Unmarshal(resp.Body, &v); err != nil {
+		return nil, err
+	}
+	pageSize, err := strconv.Atoi(v.PageSize)
+	if err != nil || pageSize < 1 {
+		return nil, &invalidResponseError{resp}
+	}
+	return &SearchIterator{
+		ctx:      ctx,
+		fetch:    fetch,
+		ids:      v.Ids.Item,
+		pageSize: pageSize,
+	}, nil
+}
+
+// TotalItems returns the number of entities matching the search.
+func (it *SearchIterator) TotalItems() int {
+	return len(it.ids)
+}
+
+// Ids returns the ids of all entities matching the search.
+func (it *SearchIterator) Ids() []string {
+	return it.ids
+}
+
+// Next advances to the next result, fetching the next page if needed. It returns
+// false at the end of the results or when fetching a page failed, see Err.
+func (it *SearchIterator) Next() bool {
+	if it.done {
+		return false
+	}
+	if it.pages == nil {
+		it.start()
+	}
+	for len(it.items) == 0 {
+		page, ok := <-it.pages
+		if !ok {
+			it.Close()
+			return false
+		}
+		p := <-page
+		if p.err != nil {
+			it.err = p.err
+			it.Close()
+			return false
+		}
+		it.items = p.items
+	}
+	it.item, it.items = it.items[0], it.items[1:]
+	return true
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *SearchIterator) Err() error {
+	return it.err
+}
+
+// Close stops the iteration and cancels any page fetches still in progress.
+func (it *SearchIterator) Close() {
+	it.done = true
+	it.item, it.items = nil, nil
+	if it.cancel != nil {
+		it.cancel()
+	}
+}
+
+func (it *SearchIterator) current() interface{} {
+	return it.item
+}
+
+func (it *SearchIterator) start() {
+	concurrency := it.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	ctx, cancel := context.WithCancel(it.ctx)
+	it.cancel = cancel
+
+	// Pages are queued in order while their fetches run. The page being consumed is
+	// not in the queue, so at most concurrency fetches are ever in flight.
+	pages := make(chan chan searchPage, concurrency-1)
+	it.pages = pages
+	go func() {
+		defer close(pages)
+		for start := 0; start < len(it.ids); start += it.pageSize {
+			end := start + it.pageSize
+			if end > len(it.ids) {
+				end = len(it.ids)
+			}
+			page := make(chan searchPage, 1)
+			select {
+			case pages <- page:
+			case <-ctx.Done():
+				return
+			}
+			go func(ids []string) {
+				items, err := it.fetch(ctx, ids)
+				page <- searchPage{items, err}
+			}(it.ids[start:end])
+		}
+	}()
+}
+
+// idsQuery returns a query matching the entities with the given ids.
+func idsQuery(ids []string) *SearchQuery {
+	query := new(SearchQuery)
+	f := query.AddMultiField("ids")
+	f.Items = ids
+	return query
+}
+
+// TransactionIterator iterates over the transactions matching a search.
+type TransactionIterator struct {
+	*SearchIterator
+}
+
+// Transaction returns the current transaction.
+func (it *TransactionIterator) Transaction() *Transaction {
+	tx, _ := it.current().(*Transaction)
+	return tx
+}
+
+// CustomerIterator iterates over the customers matching a search.
+type CustomerIterator struct {
+	*SearchIterator
+}
+
+// Customer returns the current customer.
+func (it *CustomerIterator) Customer() *Customer {
+	c, _ := it.current().(*Customer)
+	return c
+}
+
+// SubscriptionIterator iterates over the subscriptions matching a search.
+type SubscriptionIterator struct {
+	*SearchIterator
+}
+
+// Subscription returns the current subscription.
+func (it *SubscriptionIterator) Subscription() *Subscription {
+	sub, _ := it.current().(*Subscription)
+	return sub
+}
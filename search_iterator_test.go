@@ -0,0 +1,214 @@
+package braintree
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var searchItemRegexp = regexp.MustCompile(`<item>([^<]*)</item>`)
+
+// searchServer serves a two phase transaction search over ids with the given page size.
+// Requests for ids contained in fail are answered with a server error.
+func searchServer(ids []string, pageSize int, pageFetches *int32, inFlight, maxInFlight *int32, fail string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/merchants/merchant-id/transactions/advanced_search_ids":
+			body := fmt.Sprintf("<search-results><page-size>%d</page-size><ids type=\"array\">", pageSize)
+			for _, id := range ids {
+				body += "<item>" + id + "</item>"
+			}
+			writeXML(w, http.StatusOK, body+"</ids></search-results>")
+		case "/merchants/merchant-id/transactions/advanced_search":
+			atomic.AddInt32(pageFetches, 1)
+			n := atomic.AddInt32(inFlight, 1)
+			defer atomic.AddInt32(inFlight, -1)
+			for {
+				max := atomic.LoadInt32(maxInFlight)
+				if n <= max || atomic.CompareAndSwapInt32(maxInFlight, max, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+
+			req, _ := ioutil.ReadAll(r.Body)
+			body := "<credit-card-transactions>"
+			for _, m := range searchItemRegexp.FindAllStringSubmatch(string(req), -1) {
+				if m[1] == fail {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				body += "<transaction><id>" + m[1] + "</id></transaction>"
+			}
+			writeXML(w, http.StatusOK, body+"</credit-card-transactions>")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestSearchIterator(t *testing.T) {
+	t.Parallel()
+
+	ids := []string{"a", "b", "c", "d", "e"}
+	var fetches, inFlight, maxInFlight int32
+	srv := searchServer(ids, 2, &fetches, &inFlight, &maxInFlight, "")
+	defer srv.Close()
+
+	it, err := testServerGateway(srv).Transaction().SearchAll(context.Background(), new(SearchQuery))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := it.TotalItems(); n != 5 {
+		t.Fatalf("got %d total items, want 5", n)
+	}
+
+	var got []string
+	for it.Next() {
+		got = append(got, it.Transaction().Id)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, ids) {
+		t.Fatalf("got %v, want %v", got, ids)
+	}
+	if fetches != 3 {
+		t.Fatalf("got %d page fetches, want 3", fetches)
+	}
+	if maxInFlight != 1 {
+		t.Fatalf("got %d concurrent page fetches, want 1", maxInFlight)
+	}
+	if it.Next() {
+		t.Fatal("expected the iterator to stay at the end")
+	}
+}
+
+func TestSearchIteratorConcurrency(t *testing.T) {
+	t.Parallel()
+
+	var ids []string
+	for i := 0; i < 20; i++ {
+		ids = append(ids, fmt.Sprint(i))
+	}
+	var fetches, inFlight, maxInFlight int32
+	srv := searchServer(ids, 2, &fetches, &inFlight, &maxInFlight, "")
+	defer srv.Close()
+
+	it, err := testServerGateway(srv).Transaction().SearchAll(context.Background(), new(SearchQuery))
+	if err != nil {
+		t.Fatal(err)
+	}
+	it.Concurrency = 3
+
+	var got []string
+	for it.Next() {
+		got = append(got, it.Transaction().Id)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, ids) {
+		t.Fatalf("got %v, want %v", got, ids)
+	}
+	if max := atomic.LoadInt32(&maxInFlight); max < 2 || max > 3 {
+		t.Fatalf("got %d concurrent page fetches, want 2 or 3", max)
+	}
+}
+
+func TestSearchIteratorEarlyTermination(t *testing.T) {
+	t.Parallel()
+
+	ids := []string{"a", "b", "c", "d", "e"}
+	var fetches, inFlight, maxInFlight int32
+	srv := searchServer(ids, 2, &fetches, &inFlight, &maxInFlight, "")
+	defer srv.Close()
+
+	it, err := testServerGateway(srv).Transaction().SearchAll(context.Background(), new(SearchQuery))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !it.Next() || it.Transaction().Id != "a" {
+		t.Fatal("expected the first transaction")
+	}
+	it.Close()
+
+	if it.Next() {
+		t.Fatal("expected no more transactions after Close")
+	}
+	if it.Transaction() != nil {
+		t.Fatal("expected no current transaction after Close")
+	}
+	if n := atomic.LoadInt32(&fetches); n > 2 {
+		t.Fatalf("got %d page fetches, want at most 2", n)
+	}
+}
+
+func TestSearchIteratorError(t *testing.T) {
+	t.Parallel()
+
+	ids := []string{"a", "b", "c", "d", "e"}
+	var fetches, inFlight, maxInFlight int32
+	srv := searchServer(ids, 2, &fetches, &inFlight, &maxInFlight, "c")
+	defer srv.Close()
+
+	it, err := testServerGateway(srv).Transaction().SearchAll(context.Background(), new(SearchQuery))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for it.Next() {
+		got = append(got, it.Transaction().Id)
+	}
+	if !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Fatalf("got %v", got)
+	}
+	if err := it.Err(); err == nil || !strings.Contains(err.Error(), "500") {
+		t.Fatalf("got %v, want a server error", err)
+	}
+}
+
+func TestSubscriptionSearch(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/merchants/merchant-id/subscriptions/advanced_search_ids":
+			writeXML(w, http.StatusOK, `<search-results><page-size>50</page-size><ids type="array"><item>sub1</item><item>sub2</item></ids></search-results>`)
+		case "/merchants/merchant-id/subscriptions/advanced_search":
+			writeXML(w, http.StatusOK, `<subscriptions type="array"><subscription><id>sub1</id><status>Past Due</status></subscription><subscription><id>sub2</id><status>Past Due</status></subscription></subscriptions>`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	s := new(SubscriptionSearch)
+	s.Status().In(SubscriptionStatusPastDue)
+
+	it, err := testServerGateway(srv).Subscription().Search(context.Background(), s.Query())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for it.Next() {
+		if status := it.Subscription().Status; status != SubscriptionStatusPastDue {
+			t.Fatalf("got status %q", status)
+		}
+		got = append(got, it.Subscription().Id)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, []string{"sub1", "sub2"}) || it.TotalItems() != 2 {
+		t.Fatalf("got %v", got)
+	}
+}
@@ -158,3 +158,191 @@ func TestSearchResultUnmarshal(t *testing.T) {
 		t.Fatal(x)
 	}
 }
+
+func TestTransactionSearchQuery(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2016, time.September, 11, 0, 0, 0, 0, time.UTC)
+
+	s := new(TransactionSearch)
+	s.CustomerId().Is("customer-1")
+	s.Status().In(TransactionStatusSettled)
+	s.Amount().Between(NewDecimal(0, 2), NewDecimal(1000, 2))
+	s.CreatedAt().AtLeast(start)
+	s.Refund().Is(false)
+	s.Status().In(TransactionStatusSettling)
+	s.CreditCardNumber().StartsWith("4111")
+
+	b, err := xml.MarshalIndent(s.Query(), "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := `<search>
+  <customer-id>
+    <is>customer-1</is>
+  </customer-id>
+  <status type="array">
+    <item>settled</item>
+    <item>settling</item>
+  </status>
+  <amount>
+    <min>0.00</min>
+    <max>10.00</max>
+  </amount>
+  <created-at>
+    <min type="datetime">2016-09-11T00:00:00Z</min>
+  </created-at>
+  <refund>false</refund>
+  <credit-card-number>
+    <starts-with>4111</starts-with>
+  </credit-card-number>
+</search>`
+
+	if xmls := string(b); xmls != expect {
+		t.Fatalf("got %s, want %s", xmls, expect)
+	}
+}
+
+func TestCustomerSearchQuery(t *testing.T) {
+	t.Parallel()
+
+	s := new(CustomerSearch)
+	s.Email().EndsWith("@example.com")
+	s.CreditCardExpirationDate().Is("05/2020")
+	s.PayPalAccountEmail().Is("payer@example.com")
+	s.Email().IsNot("someone@example.com")
+	s.CustomField("tenant_id").Is("acme")
+
+	b, err := xml.MarshalIndent(s.Query(), "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := `<search>
+  <email>
+    <is-not>someone@example.com</is-not>
+    <ends-with>@example.com</ends-with>
+  </email>
+  <credit-card-expiration-date>
+    <is>05/2020</is>
+  </credit-card-expiration-date>
+  <paypal-account-email>
+    <is>payer@example.com</is>
+  </paypal-account-email>
+  <tenant_id>
+    <is>acme</is>
+  </tenant_id>
+</search>`
+
+	if xmls := string(b); xmls != expect {
+		t.Fatalf("got %s, want %s", xmls, expect)
+	}
+}
+
+func TestSearchQueryLeavesOutEmptyCriteria(t *testing.T) {
+	t.Parallel()
+
+	s := new(TransactionSearch)
+	s.Status()
+	s.Amount()
+	s.CreatedAt()
+	s.Refund()
+	s.CustomerId()
+	s.Status().In()
+	s.Id().Is("abc123")
+
+	b, err := xml.Marshal(s.Query())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := `<search><id><is>abc123</is></id></search>`
+	if xmls := string(b); xmls != expect {
+		t.Fatalf("got %s, want %s", xmls, expect)
+	}
+
+	d := new(DisputeSearch)
+	d.ReceivedDate()
+	d.Status()
+	b, err = xml.Marshal(d.Query())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if xmls := string(b); xmls != `<search></search>` {
+		t.Fatalf("got %s, want an empty search", xmls)
+	}
+}
+
+func TestSubscriptionSearchQuery(t *testing.T) {
+	t.Parallel()
+
+	s := new(SubscriptionSearch)
+	s.Status().In(SubscriptionStatusPastDue)
+	s.DaysPastDue().AtLeast(0)
+	s.NextBillingDate().AtMost(time.Date(2016, time.September, 11, 0, 0, 0, 0, time.UTC))
+	s.InTrialPeriod().Is(false)
+
+	b, err := xml.MarshalIndent(s.Query(), "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := `<search>
+  <status type="array">
+    <item>Past Due</item>
+  </status>
+  <days-past-due>
+    <min>0</min>
+  </days-past-due>
+  <next-billing-date>
+    <max type="date">2016-09-11</max>
+  </next-billing-date>
+  <in-trial-period type="array">
+    <item>false</item>
+  </in-trial-period>
+</search>`
+
+	if xmls := string(b); xmls != expect {
+		t.Fatalf("got %s, want %s", xmls, expect)
+	}
+}
+
+func TestDisputeSearchQuery(t *testing.T) {
+	t.Parallel()
+
+	s := new(DisputeSearch)
+	s.Status().In(DisputeStatusOpen, DisputeStatusDisputed)
+	s.Reason().In(DisputeReasonFraud)
+	s.ReceivedDate().Between(time.Date(2017, time.June, 1, 0, 0, 0, 0, time.UTC), time.Date(2017, time.June, 30, 0, 0, 0, 0, time.UTC))
+	s.AmountDisputed().AtLeast(NewDecimal(5000, 2))
+	s.TransactionId().Is("tx1")
+
+	b, err := xml.MarshalIndent(s.Query(), "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expect := `<search>
+  <status type="array">
+    <item>open</item>
+    <item>disputed</item>
+  </status>
+  <reason type="array">
+    <item>fraud</item>
+  </reason>
+  <received-date>
+    <min type="date">2017-06-01</min>
+    <max type="date">2017-06-30</max>
+  </received-date>
+  <amount-disputed>
+    <min>50.00</min>
+  </amount-disputed>
+  <transaction-id>
+    <is>tx1</is>
+  </transaction-id>
+</search>`
+
+	if xmls := string(b); xmls != expect {
+		t.Fatalf("got %s, want %s", xmls, expect)
+	}
+}
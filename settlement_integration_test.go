@@ -1,6 +1,7 @@
 package braintree
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"sort"
@@ -16,10 +17,12 @@ const (
 func TestSettlementBatch(t *testing.T) {
 	t.Parallel()
 
+	ctx := context.Background()
+
 	// Get current batch summary
 	y, m, d := time.Now().Date()
 	date := fmt.Sprintf("%d-%d-%d", y, m, d)
-	batchSummary, err := testGateway.Settlement().Generate(&Settlement{Date: date})
+	batchSummary, err := testGateway.Settlement().Generate(ctx, &Settlement{Date: date})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -32,7 +35,7 @@ func TestSettlementBatch(t *testing.T) {
 	}
 
 	// Create a new transaction to add 12.34 to the summary
-	tx, err := testGateway.Transaction().Create(&Transaction{
+	tx, err := testGateway.Transaction().Create(ctx, &Transaction{
 		Type:   "sale",
 		Amount: NewDecimal(1234, 2),
 		CreditCard: &CreditCard{
@@ -53,7 +56,7 @@ func TestSettlementBatch(t *testing.T) {
 
 	// Submit for settlement
 	ten := NewDecimal(1234, 2)
-	tx2, err := testGateway.Transaction().SubmitForSettlement(tx.Id, ten)
+	tx2, err := testGateway.Transaction().SubmitForSettlement(ctx, tx.Id, ten)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -66,7 +69,7 @@ func TestSettlementBatch(t *testing.T) {
 	}
 
 	// Settle
-	tx3, err := testGateway.Transaction().Settle(tx.Id)
+	tx3, err := testGateway.Transaction().Settle(ctx, tx.Id)
 	t.Log(tx3)
 	if err != nil {
 		t.Fatal(err)
@@ -76,7 +79,7 @@ func TestSettlementBatch(t *testing.T) {
 	}
 
 	// Generate Settlement Batch Summary which will include new transaction
-	batchSummary, err = testGateway.Settlement().Generate(&Settlement{Date: date})
+	batchSummary, err = testGateway.Settlement().Generate(ctx, &Settlement{Date: date})
 	if err != nil {
 		t.Fatal(fmt.Sprintf("Unable to get settlement batch: err is %s", err.Error()))
 	}
@@ -1,6 +1,11 @@
 package braintree
 
-import "github.com/lionelbarrow/braintree-go/nullable"
+import (
+	"time"
+
+	"github.com/lionelbarrow/braintree-go/date"
+	"github.com/lionelbarrow/braintree-go/nullable"
+)
 
 const (
 	SubscriptionStatusActive       = "Active"
@@ -11,61 +16,82 @@ const (
 	SubscriptionStatusUnrecognized = "Unrecognized"
 )
 
+const (
+	SubscriptionSourceAPI          = "api"
+	SubscriptionSourceControlPanel = "control_panel"
+	SubscriptionSourceRecurring    = "recurring"
+)
+
 const (
 	SubscriptionTrialDurationUnitDay   = "day"
 	SubscriptionTrialDurationUnitMonth = "month"
 )
 
 type Subscription struct {
-	XMLName                 string               `xml:"subscription"`
-	Id                      string               `xml:"id,omitempty"`
-	Balance                 *Decimal             `xml:"balance,omitempty"`
-	BillingDayOfMonth       string               `xml:"billing-day-of-month,omitempty"`
-	BillingPeriodEndDate    string               `xml:"billing-period-end-date,omitempty"`
-	BillingPeriodStartDate  string               `xml:"billing-period-start-date,omitempty"`
-	CurrentBillingCycle     string               `xml:"current-billing-cycle,omitempty"`
-	DaysPastDue             string               `xml:"days-past-due,omitempty"`
-	Discounts               []interface{}        `xml:"discounts,omitempty"`
-	FailureCount            string               `xml:"failure-count,omitempty"`
-	FirstBillingDate        string               `xml:"first-billing-date,omitempty"`
-	MerchantAccountId       string               `xml:"merchant-account-id,omitempty"`
-	NeverExpires            *nullable.NullBool   `xml:"never-expires,omitempty"`
-	NextBillAmount          *Decimal             `xml:"next-bill-amount,omitempty"`
-	NextBillingPeriodAmount *Decimal             `xml:"next-billing-period-amount,omitempty"`
-	NextBillingDate         string               `xml:"next-billing-date,omitempty"`
-	NumberOfBillingCycles   *nullable.NullInt64  `xml:"number-of-billing-cycles,omitempty"`
-	PaidThroughDate         string               `xml:"paid-through-date,omitempty"`
-	PaymentMethodToken      string               `xml:"payment-method-token,omitempty"`
-	PlanId                  string               `xml:"plan-id,omitempty"`
-	Price                   *Decimal             `xml:"price,omitempty"`
-	Status                  string               `xml:"status,omitempty"`
-	TrialDuration           string               `xml:"trial-duration,omitempty"`
-	TrialDurationUnit       string               `xml:"trial-duration-unit,omitempty"`
-	TrialPeriod             *nullable.NullBool   `xml:"trial-period,omitempty"`
-	Transactions            *Transactions        `xml:"transactions,omitempty"`
-	Options                 *SubscriptionOptions `xml:"options,omitempty"`
-	Descriptor              *Descriptor          `xml:"descriptor,omitempty"`
-	// AddOns                  []interface{} `xml:"add-ons,omitempty"`
+	XMLName                 string                     `xml:"subscription"`
+	Id                      string                     `xml:"id,omitempty"`
+	Balance                 *Decimal                   `xml:"balance,omitempty"`
+	BillingDayOfMonth       string                     `xml:"billing-day-of-month,omitempty"`
+	BillingPeriodEndDate    *date.Date                 `xml:"billing-period-end-date,omitempty"`
+	BillingPeriodStartDate  *date.Date                 `xml:"billing-period-start-date,omitempty"`
+	CurrentBillingCycle     string                     `xml:"current-billing-cycle,omitempty"`
+	DaysPastDue             int                        `xml:"days-past-due,omitempty"`
+	Discounts               DiscountList               `xml:"discounts"`
+	FailureCount            int                        `xml:"failure-count,omitempty"`
+	FirstBillingDate        *date.Date                 `xml:"first-billing-date,omitempty"`
+	MerchantAccountId       string                     `xml:"merchant-account-id,omitempty"`
+	NeverExpires            *nullable.NullBool         `xml:"never-expires,omitempty"`
+	NextBillAmount          *Decimal                   `xml:"next-bill-amount,omitempty"`
+	NextBillingPeriodAmount *Decimal                   `xml:"next-billing-period-amount,omitempty"`
+	NextBillingDate         *date.Date                 `xml:"next-billing-date,omitempty"`
+	NumberOfBillingCycles   *nullable.NullInt64        `xml:"number-of-billing-cycles,omitempty"`
+	PaidThroughDate         *date.Date                 `xml:"paid-through-date,omitempty"`
+	PaymentMethodToken      string                     `xml:"payment-method-token,omitempty"`
+	PlanId                  string                     `xml:"plan-id,omitempty"`
+	Price                   *Decimal                   `xml:"price,omitempty"`
+	Status                  string                     `xml:"status,omitempty"`
+	TrialDuration           string                     `xml:"trial-duration,omitempty"`
+	TrialDurationUnit       string                     `xml:"trial-duration-unit,omitempty"`
+	TrialPeriod             *nullable.NullBool         `xml:"trial-period,omitempty"`
+	Transactions            *Transactions              `xml:"transactions,omitempty"`
+	Options                 *SubscriptionOptions       `xml:"options,omitempty"`
+	Descriptor              *Descriptor                `xml:"descriptor,omitempty"`
+	AddOns                  AddOnList                  `xml:"add-ons"`
+	StatusHistory           []*SubscriptionStatusEvent `xml:"status-history>status-event,omitempty"`
+}
+
+// SubscriptionStatusEvent records a change to a subscription, e.g. it going past due.
+type SubscriptionStatusEvent struct {
+	Timestamp *time.Time `xml:"timestamp"`
+	Status    string     `xml:"status"`
+	Price     *Decimal   `xml:"price"`
+	Balance   *Decimal   `xml:"balance"`
+	User      string     `xml:"user"`
+	// SubscriptionSource is what caused the change, see the SubscriptionSource constants.
+	SubscriptionSource string `xml:"subscription-source"`
+	PlanId             string `xml:"plan-id"`
 }
 
 type SubscriptionRequest struct {
-	XMLName               string               `xml:"subscription"`
-	Id                    string               `xml:"id,omitempty"`
-	BillingDayOfMonth     *nullable.NullInt64  `xml:"billing-day-of-month,omitempty"`
-	FailureCount          string               `xml:"failure-count,omitempty"`
-	FirstBillingDate      string               `xml:"first-billing-date,omitempty"`
-	MerchantAccountId     string               `xml:"merchant-account-id,omitempty"`
-	NeverExpires          *nullable.NullBool   `xml:"never-expires,omitempty"`
-	NumberOfBillingCycles *nullable.NullInt64  `xml:"number-of-billing-cycles,omitempty"`
-	Options               *SubscriptionOptions `xml:"options,omitempty"`
-	PaymentMethodNonce    string               `xml:"paymentMethodNonce,omitempty"`
-	PaymentMethodToken    string               `xml:"paymentMethodToken,omitempty"`
-	PlanId                string               `xml:"planId,omitempty"`
-	Price                 *Decimal             `xml:"price,omitempty"`
-	TrialDuration         string               `xml:"trial-duration,omitempty"`
-	TrialDurationUnit     string               `xml:"trial-duration-unit,omitempty"`
-	TrialPeriod           *nullable.NullBool   `xml:"trial-period,omitempty"`
-	Descriptor            *Descriptor          `xml:"descriptor,omitempty"`
+	XMLName               string                `xml:"subscription"`
+	Id                    string                `xml:"id,omitempty"`
+	BillingDayOfMonth     *nullable.NullInt64   `xml:"billing-day-of-month,omitempty"`
+	FailureCount          string                `xml:"failure-count,omitempty"`
+	FirstBillingDate      string                `xml:"first-billing-date,omitempty"`
+	MerchantAccountId     string                `xml:"merchant-account-id,omitempty"`
+	NeverExpires          *nullable.NullBool    `xml:"never-expires,omitempty"`
+	NumberOfBillingCycles *nullable.NullInt64   `xml:"number-of-billing-cycles,omitempty"`
+	Options               *SubscriptionOptions  `xml:"options,omitempty"`
+	PaymentMethodNonce    string                `xml:"paymentMethodNonce,omitempty"`
+	PaymentMethodToken    string                `xml:"paymentMethodToken,omitempty"`
+	PlanId                string                `xml:"planId,omitempty"`
+	Price                 *Decimal              `xml:"price,omitempty"`
+	TrialDuration         string                `xml:"trial-duration,omitempty"`
+	TrialDurationUnit     string                `xml:"trial-duration-unit,omitempty"`
+	TrialPeriod           *nullable.NullBool    `xml:"trial-period,omitempty"`
+	Descriptor            *Descriptor           `xml:"descriptor,omitempty"`
+	AddOns                *ModificationsRequest `xml:"add-ons,omitempty"`
+	Discounts             *ModificationsRequest `xml:"discounts,omitempty"`
 }
 
 type Subscriptions struct {
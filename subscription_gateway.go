@@ -1,11 +1,16 @@
 package braintree
 
+import (
+	"context"
+	"encoding/xml"
+)
+
 type SubscriptionGateway struct {
 	*Braintree
 }
 
-func (g *SubscriptionGateway) Create(sub *SubscriptionRequest) (*Subscription, error) {
-	resp, err := g.execute("POST", "subscriptions", sub)
+func (g *SubscriptionGateway) Create(ctx context.Context, sub *SubscriptionRequest) (*Subscription, error) {
+	resp, err := g.execute(ctx, "Subscription", "POST", "subscriptions", sub)
 	if err != nil {
 		return nil, err
 	}
@@ -16,8 +21,8 @@ func (g *SubscriptionGateway) Create(sub *SubscriptionRequest) (*Subscription, e
 	return nil, &invalidResponseError{resp}
 }
 
-func (g *SubscriptionGateway) Update(sub *SubscriptionRequest) (*Subscription, error) {
-	resp, err := g.execute("PUT", "subscriptions/"+sub.Id, sub)
+func (g *SubscriptionGateway) Update(ctx context.Context, sub *SubscriptionRequest) (*Subscription, error) {
+	resp, err := g.execute(ctx, "Subscription", "PUT", "subscriptions/"+sub.Id, sub)
 	if err != nil {
 		return nil, err
 	}
@@ -28,8 +33,8 @@ func (g *SubscriptionGateway) Update(sub *SubscriptionRequest) (*Subscription, e
 	return nil, &invalidResponseError{resp}
 }
 
-func (g *SubscriptionGateway) Find(subId string) (*Subscription, error) {
-	resp, err := g.execute("GET", "subscriptions/"+subId, nil)
+func (g *SubscriptionGateway) Find(ctx context.Context, subId string) (*Subscription, error) {
+	resp, err := g.execute(ctx, "Subscription", "GET", "subscriptions/"+subId, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -40,8 +45,8 @@ func (g *SubscriptionGateway) Find(subId string) (*Subscription, error) {
 	return nil, &invalidResponseError{resp}
 }
 
-func (g *SubscriptionGateway) Cancel(subId string) (*Subscription, error) {
-	resp, err := g.execute("PUT", "subscriptions/"+subId+"/cancel", nil)
+func (g *SubscriptionGateway) Cancel(ctx context.Context, subId string) (*Subscription, error) {
+	resp, err := g.execute(ctx, "Subscription", "PUT", "subscriptions/"+subId+"/cancel", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -51,3 +56,57 @@ func (g *SubscriptionGateway) Cancel(subId string) (*Subscription, error) {
 	}
 	return nil, &invalidResponseError{resp}
 }
+
+// RetryCharge retries charging a past due subscription, returning the resulting
+// transaction. If amount is nil the subscription's balance is charged. Errors are
+// returned as by TransactionGateway.Create.
+func (g *SubscriptionGateway) RetryCharge(ctx context.Context, subId string, amount *Decimal, submitForSettlement bool) (*Transaction, error) {
+	tx := &Transaction{
+		Type:           TransactionTypeSale,
+		SubscriptionId: subId,
+		Amount:         amount,
+	}
+	if submitForSettlement {
+		tx.Options = &TransactionOptions{SubmitForSettlement: true}
+	}
+	resp, err := g.execute(ctx, "Subscription", "POST", "transactions", tx)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case 201:
+		return resp.transaction()
+	}
+	return nil, &invalidResponseError{resp}
+}
+
+// Search finds all subscriptions matching the search query. The returned iterator
+// fetches the subscriptions a page at a time as it advances.
+func (g *SubscriptionGateway) Search(ctx context.Context, query *SearchQuery) (*SubscriptionIterator, error) {
+	it, err := newSearchIterator(ctx, g.Braintree, "Subscription", "subscriptions", query, g.fetchSubscriptions)
+	if err != nil {
+		return nil, err
+	}
+	return &SubscriptionIterator{it}, nil
+}
+
+func (g *SubscriptionGateway) fetchSubscriptions(ctx context.Context, ids []string) ([]interface{}, error) {
+	resp, err := g.execute(ctx, "Subscription", "POST", "subscriptions/advanced_search", idsQuery(ids))
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case 200:
+	default:
+		return nil, &invalidResponseError{resp}
+	}
+	var v Subscriptions
+	if err := xml.Unmarshal(resp.Body, &v); err != nil {
+		return nil, err
+	}
+	items := make([]interface{}, len(v.Subscription))
+	for i, sub := range v.Subscription {
+		items[i] = sub
+	}
+	return items, nil
+}
@@ -1,6 +1,7 @@
 package braintree
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -12,11 +13,13 @@ import (
 func TestSubscriptionSimple(t *testing.T) {
 	t.Parallel()
 
-	customer, err := testGateway.Customer().Create(&Customer{})
+	ctx := context.Background()
+
+	customer, err := testGateway.Customer().Create(ctx, &Customer{})
 	if err != nil {
 		t.Fatal(err)
 	}
-	paymentMethod, err := testGateway.PaymentMethod().Create(&PaymentMethodRequest{
+	paymentMethod, err := testGateway.PaymentMethod().Create(ctx, &PaymentMethodRequest{
 		CustomerId:         customer.Id,
 		PaymentMethodNonce: FakeNonceTransactable,
 	})
@@ -29,7 +32,7 @@ func TestSubscriptionSimple(t *testing.T) {
 	g := testGateway.Subscription()
 
 	// Create
-	sub, err := g.Create(&SubscriptionRequest{
+	sub, err := g.Create(ctx, &SubscriptionRequest{
 		PaymentMethodToken: paymentMethod.GetToken(),
 		PlanId:             "test_plan",
 	})
@@ -44,7 +47,7 @@ func TestSubscriptionSimple(t *testing.T) {
 	}
 
 	// Update
-	sub2, err := g.Update(&SubscriptionRequest{
+	sub2, err := g.Update(ctx, &SubscriptionRequest{
 		Id:     sub.Id,
 		PlanId: "test_plan_2",
 		Options: &SubscriptionOptions{
@@ -67,7 +70,7 @@ func TestSubscriptionSimple(t *testing.T) {
 	}
 
 	// Find
-	sub3, err := g.Find(sub.Id)
+	sub3, err := g.Find(ctx, sub.Id)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -75,8 +78,24 @@ func TestSubscriptionSimple(t *testing.T) {
 		t.Fatal(sub3.Id)
 	}
 
+	// Search
+	query := new(SubscriptionSearch)
+	query.Id().Is(sub.Id)
+	query.PlanId().In("test_plan_2")
+	it, err := g.Search(ctx, query.Query())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !it.Next() || it.Subscription().Id != sub.Id {
+		t.Fatal("could not search for the subscription")
+	}
+	it.Close()
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+
 	// Cancel
-	_, err = g.Cancel(sub2.Id)
+	_, err = g.Cancel(ctx, sub2.Id)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -85,11 +104,13 @@ func TestSubscriptionSimple(t *testing.T) {
 func TestSubscriptionAllFieldsWithBillingDayOfMonth(t *testing.T) {
 	t.Parallel()
 
-	customer, err := testGateway.Customer().Create(&Customer{})
+	ctx := context.Background()
+
+	customer, err := testGateway.Customer().Create(ctx, &Customer{})
 	if err != nil {
 		t.Fatal(err)
 	}
-	paymentMethod, err := testGateway.PaymentMethod().Create(&PaymentMethodRequest{
+	paymentMethod, err := testGateway.PaymentMethod().Create(ctx, &PaymentMethodRequest{
 		CustomerId:         customer.Id,
 		PaymentMethodNonce: FakeNonceTransactable,
 	})
@@ -104,7 +125,7 @@ func TestSubscriptionAllFieldsWithBillingDayOfMonth(t *testing.T) {
 	// Create
 	billingDayOfMonth := nullable.NewNullInt64(15, true)
 	numberOfBillingCycles := nullable.NewNullInt64(2, true)
-	sub1, err := g.Create(&SubscriptionRequest{
+	sub1, err := g.Create(ctx, &SubscriptionRequest{
 		PaymentMethodToken:    paymentMethod.GetToken(),
 		PlanId:                "test_plan",
 		MerchantAccountId:     testMerchantAccountId,
@@ -152,7 +173,7 @@ func TestSubscriptionAllFieldsWithBillingDayOfMonth(t *testing.T) {
 	}
 
 	// Update
-	sub2, err := g.Update(&SubscriptionRequest{
+	sub2, err := g.Update(ctx, &SubscriptionRequest{
 		Id:     sub1.Id,
 		PlanId: "test_plan_2",
 		Options: &SubscriptionOptions{
@@ -175,7 +196,7 @@ func TestSubscriptionAllFieldsWithBillingDayOfMonth(t *testing.T) {
 	}
 
 	// Find
-	sub3, err := g.Find(sub1.Id)
+	sub3, err := g.Find(ctx, sub1.Id)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -184,7 +205,7 @@ func TestSubscriptionAllFieldsWithBillingDayOfMonth(t *testing.T) {
 	}
 
 	// Cancel
-	_, err = g.Cancel(sub1.Id)
+	_, err = g.Cancel(ctx, sub1.Id)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -193,11 +214,13 @@ func TestSubscriptionAllFieldsWithBillingDayOfMonth(t *testing.T) {
 func TestSubscriptionAllFieldsWithBillingDayOfMonthNeverExpires(t *testing.T) {
 	t.Parallel()
 
-	customer, err := testGateway.Customer().Create(&Customer{})
+	ctx := context.Background()
+
+	customer, err := testGateway.Customer().Create(ctx, &Customer{})
 	if err != nil {
 		t.Fatal(err)
 	}
-	paymentMethod, err := testGateway.PaymentMethod().Create(&PaymentMethodRequest{
+	paymentMethod, err := testGateway.PaymentMethod().Create(ctx, &PaymentMethodRequest{
 		CustomerId:         customer.Id,
 		PaymentMethodNonce: FakeNonceTransactable,
 	})
@@ -212,7 +235,7 @@ func TestSubscriptionAllFieldsWithBillingDayOfMonthNeverExpires(t *testing.T) {
 	// Create
 	billingDayOfMonth := nullable.NewNullInt64(15, true)
 	neverExpires := nullable.NewNullBool(true, true)
-	sub1, err := g.Create(&SubscriptionRequest{
+	sub1, err := g.Create(ctx, &SubscriptionRequest{
 		PaymentMethodToken: paymentMethod.GetToken(),
 		PlanId:             "test_plan",
 		MerchantAccountId:  testMerchantAccountId,
@@ -260,7 +283,7 @@ func TestSubscriptionAllFieldsWithBillingDayOfMonthNeverExpires(t *testing.T) {
 	}
 
 	// Update
-	sub2, err := g.Update(&SubscriptionRequest{
+	sub2, err := g.Update(ctx, &SubscriptionRequest{
 		Id:     sub1.Id,
 		PlanId: "test_plan_2",
 		Options: &SubscriptionOptions{
@@ -283,7 +306,7 @@ func TestSubscriptionAllFieldsWithBillingDayOfMonthNeverExpires(t *testing.T) {
 	}
 
 	// Find
-	sub3, err := g.Find(sub1.Id)
+	sub3, err := g.Find(ctx, sub1.Id)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -292,7 +315,7 @@ func TestSubscriptionAllFieldsWithBillingDayOfMonthNeverExpires(t *testing.T) {
 	}
 
 	// Cancel
-	_, err = g.Cancel(sub1.Id)
+	_, err = g.Cancel(ctx, sub1.Id)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -301,11 +324,13 @@ func TestSubscriptionAllFieldsWithBillingDayOfMonthNeverExpires(t *testing.T) {
 func TestSubscriptionAllFieldsWithFirstBillingDate(t *testing.T) {
 	t.Parallel()
 
-	customer, err := testGateway.Customer().Create(&Customer{})
+	ctx := context.Background()
+
+	customer, err := testGateway.Customer().Create(ctx, &Customer{})
 	if err != nil {
 		t.Fatal(err)
 	}
-	paymentMethod, err := testGateway.PaymentMethod().Create(&PaymentMethodRequest{
+	paymentMethod, err := testGateway.PaymentMethod().Create(ctx, &PaymentMethodRequest{
 		CustomerId:         customer.Id,
 		PaymentMethodNonce: FakeNonceTransactable,
 	})
@@ -320,7 +345,7 @@ func TestSubscriptionAllFieldsWithFirstBillingDate(t *testing.T) {
 	// Create
 	firstBillingDate := fmt.Sprintf("%d-12-31", time.Now().Year())
 	numberOfBillingCycles := nullable.NewNullInt64(2, true)
-	sub1, err := g.Create(&SubscriptionRequest{
+	sub1, err := g.Create(ctx, &SubscriptionRequest{
 		PaymentMethodToken:    paymentMethod.GetToken(),
 		PlanId:                "test_plan",
 		MerchantAccountId:     testMerchantAccountId,
@@ -345,7 +370,7 @@ func TestSubscriptionAllFieldsWithFirstBillingDate(t *testing.T) {
 	if sub1.BillingDayOfMonth != "31" {
 		t.Fatalf("got billing day of month %#v, want %#v", sub1.BillingDayOfMonth, "31")
 	}
-	if sub1.FirstBillingDate != firstBillingDate {
+	if sub1.FirstBillingDate == nil || sub1.FirstBillingDate.Format("2006-01-02") != firstBillingDate {
 		t.Fatalf("got first billing date %#v, want %#v", sub1.FirstBillingDate, firstBillingDate)
 	}
 	if x := sub1.NeverExpires; x == nil || !x.Valid || x.Bool {
@@ -371,7 +396,7 @@ func TestSubscriptionAllFieldsWithFirstBillingDate(t *testing.T) {
 	}
 
 	// Update
-	sub2, err := g.Update(&SubscriptionRequest{
+	sub2, err := g.Update(ctx, &SubscriptionRequest{
 		Id:     sub1.Id,
 		PlanId: "test_plan_2",
 		Options: &SubscriptionOptions{
@@ -394,7 +419,7 @@ func TestSubscriptionAllFieldsWithFirstBillingDate(t *testing.T) {
 	}
 
 	// Find
-	sub3, err := g.Find(sub1.Id)
+	sub3, err := g.Find(ctx, sub1.Id)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -403,7 +428,7 @@ func TestSubscriptionAllFieldsWithFirstBillingDate(t *testing.T) {
 	}
 
 	// Cancel
-	_, err = g.Cancel(sub1.Id)
+	_, err = g.Cancel(ctx, sub1.Id)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -412,11 +437,13 @@ func TestSubscriptionAllFieldsWithFirstBillingDate(t *testing.T) {
 func TestSubscriptionAllFieldsWithFirstBillingDateNeverExpires(t *testing.T) {
 	t.Parallel()
 
-	customer, err := testGateway.Customer().Create(&Customer{})
+	ctx := context.Background()
+
+	customer, err := testGateway.Customer().Create(ctx, &Customer{})
 	if err != nil {
 		t.Fatal(err)
 	}
-	paymentMethod, err := testGateway.PaymentMethod().Create(&PaymentMethodRequest{
+	paymentMethod, err := testGateway.PaymentMethod().Create(ctx, &PaymentMethodRequest{
 		CustomerId:         customer.Id,
 		PaymentMethodNonce: FakeNonceTransactable,
 	})
@@ -431,7 +458,7 @@ func TestSubscriptionAllFieldsWithFirstBillingDateNeverExpires(t *testing.T) {
 	// Create
 	firstBillingDate := fmt.Sprintf("%d-12-31", time.Now().Year())
 	neverExpires := nullable.NewNullBool(true, true)
-	sub1, err := g.Create(&SubscriptionRequest{
+	sub1, err := g.Create(ctx, &SubscriptionRequest{
 		PaymentMethodToken: paymentMethod.GetToken(),
 		PlanId:             "test_plan",
 		MerchantAccountId:  testMerchantAccountId,
@@ -456,7 +483,7 @@ func TestSubscriptionAllFieldsWithFirstBillingDateNeverExpires(t *testing.T) {
 	if sub1.BillingDayOfMonth != "31" {
 		t.Fatalf("got billing day of month %#v, want %#v", sub1.BillingDayOfMonth, "31")
 	}
-	if sub1.FirstBillingDate != firstBillingDate {
+	if sub1.FirstBillingDate == nil || sub1.FirstBillingDate.Format("2006-01-02") != firstBillingDate {
 		t.Fatalf("got first billing date %#v, want %#v", sub1.FirstBillingDate, firstBillingDate)
 	}
 	if x := sub1.NeverExpires; x == nil || !x.Valid || !x.Bool {
@@ -482,7 +509,7 @@ func TestSubscriptionAllFieldsWithFirstBillingDateNeverExpires(t *testing.T) {
 	}
 
 	// Update
-	sub2, err := g.Update(&SubscriptionRequest{
+	sub2, err := g.Update(ctx, &SubscriptionRequest{
 		Id:     sub1.Id,
 		PlanId: "test_plan_2",
 		Options: &SubscriptionOptions{
@@ -505,7 +532,7 @@ func TestSubscriptionAllFieldsWithFirstBillingDateNeverExpires(t *testing.T) {
 	}
 
 	// Find
-	sub3, err := g.Find(sub1.Id)
+	sub3, err := g.Find(ctx, sub1.Id)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -514,7 +541,7 @@ func TestSubscriptionAllFieldsWithFirstBillingDateNeverExpires(t *testing.T) {
 	}
 
 	// Cancel
-	_, err = g.Cancel(sub1.Id)
+	_, err = g.Cancel(ctx, sub1.Id)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -523,11 +550,13 @@ func TestSubscriptionAllFieldsWithFirstBillingDateNeverExpires(t *testing.T) {
 func TestSubscriptionAllFieldsWithTrialPeriod(t *testing.T) {
 	t.Parallel()
 
-	customer, err := testGateway.Customer().Create(&Customer{})
+	ctx := context.Background()
+
+	customer, err := testGateway.Customer().Create(ctx, &Customer{})
 	if err != nil {
 		t.Fatal(err)
 	}
-	paymentMethod, err := testGateway.PaymentMethod().Create(&PaymentMethodRequest{
+	paymentMethod, err := testGateway.PaymentMethod().Create(ctx, &PaymentMethodRequest{
 		CustomerId:         customer.Id,
 		PaymentMethodNonce: FakeNonceTransactable,
 	})
@@ -543,7 +572,7 @@ func TestSubscriptionAllFieldsWithTrialPeriod(t *testing.T) {
 	trialPeriod := nullable.NewNullBool(true, true)
 	firstBillingDate := time.Now().AddDate(0, 0, 7)
 	numberOfBillingCycles := nullable.NewNullInt64(2, true)
-	sub1, err := g.Create(&SubscriptionRequest{
+	sub1, err := g.Create(ctx, &SubscriptionRequest{
 		PaymentMethodToken:    paymentMethod.GetToken(),
 		PlanId:                "test_plan",
 		MerchantAccountId:     testMerchantAccountId,
@@ -570,7 +599,7 @@ func TestSubscriptionAllFieldsWithTrialPeriod(t *testing.T) {
 	if sub1.BillingDayOfMonth != fmt.Sprintf("%d", firstBillingDate.Day()) {
 		t.Fatalf("got billing day of month %#v, want %#v", sub1.BillingDayOfMonth, firstBillingDate.Day())
 	}
-	if sub1.FirstBillingDate != firstBillingDate.Format("2006-01-02") {
+	if sub1.FirstBillingDate == nil || sub1.FirstBillingDate.Format("2006-01-02") != firstBillingDate.Format("2006-01-02") {
 		t.Fatalf("got first billing date %#v, want %#v", sub1.FirstBillingDate, firstBillingDate)
 	}
 	if x := sub1.NeverExpires; x == nil || !x.Valid || x.Bool {
@@ -602,7 +631,7 @@ func TestSubscriptionAllFieldsWithTrialPeriod(t *testing.T) {
 	}
 
 	// Update
-	sub2, err := g.Update(&SubscriptionRequest{
+	sub2, err := g.Update(ctx, &SubscriptionRequest{
 		Id:     sub1.Id,
 		PlanId: "test_plan_2",
 		Options: &SubscriptionOptions{
@@ -625,7 +654,7 @@ func TestSubscriptionAllFieldsWithTrialPeriod(t *testing.T) {
 	}
 
 	// Find
-	sub3, err := g.Find(sub1.Id)
+	sub3, err := g.Find(ctx, sub1.Id)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -634,7 +663,7 @@ func TestSubscriptionAllFieldsWithTrialPeriod(t *testing.T) {
 	}
 
 	// Cancel
-	_, err = g.Cancel(sub1.Id)
+	_, err = g.Cancel(ctx, sub1.Id)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -643,11 +672,13 @@ func TestSubscriptionAllFieldsWithTrialPeriod(t *testing.T) {
 func TestSubscriptionAllFieldsWithTrialPeriodNeverExpires(t *testing.T) {
 	t.Parallel()
 
-	customer, err := testGateway.Customer().Create(&Customer{})
+	ctx := context.Background()
+
+	customer, err := testGateway.Customer().Create(ctx, &Customer{})
 	if err != nil {
 		t.Fatal(err)
 	}
-	paymentMethod, err := testGateway.PaymentMethod().Create(&PaymentMethodRequest{
+	paymentMethod, err := testGateway.PaymentMethod().Create(ctx, &PaymentMethodRequest{
 		CustomerId:         customer.Id,
 		PaymentMethodNonce: FakeNonceTransactable,
 	})
@@ -663,7 +694,7 @@ func TestSubscriptionAllFieldsWithTrialPeriodNeverExpires(t *testing.T) {
 	trialPeriod := nullable.NewNullBool(true, true)
 	firstBillingDate := time.Now().AddDate(0, 0, 7)
 	neverExpires := nullable.NewNullBool(true, true)
-	sub1, err := g.Create(&SubscriptionRequest{
+	sub1, err := g.Create(ctx, &SubscriptionRequest{
 		PaymentMethodToken: paymentMethod.GetToken(),
 		PlanId:             "test_plan",
 		MerchantAccountId:  testMerchantAccountId,
@@ -690,7 +721,7 @@ func TestSubscriptionAllFieldsWithTrialPeriodNeverExpires(t *testing.T) {
 	if sub1.BillingDayOfMonth != fmt.Sprintf("%d", firstBillingDate.Day()) {
 		t.Fatalf("got billing day of month %#v, want %#v", sub1.BillingDayOfMonth, firstBillingDate.Day())
 	}
-	if sub1.FirstBillingDate != firstBillingDate.Format("2006-01-02") {
+	if sub1.FirstBillingDate == nil || sub1.FirstBillingDate.Format("2006-01-02") != firstBillingDate.Format("2006-01-02") {
 		t.Fatalf("got first billing date %#v, want %#v", sub1.FirstBillingDate, firstBillingDate)
 	}
 	if x := sub1.NeverExpires; x == nil || !x.Valid || !x.Bool {
@@ -722,7 +753,7 @@ func TestSubscriptionAllFieldsWithTrialPeriodNeverExpires(t *testing.T) {
 	}
 
 	// Update
-	sub2, err := g.Update(&SubscriptionRequest{
+	sub2, err := g.Update(ctx, &SubscriptionRequest{
 		Id:     sub1.Id,
 		PlanId: "test_plan_2",
 		Options: &SubscriptionOptions{
@@ -745,7 +776,7 @@ func TestSubscriptionAllFieldsWithTrialPeriodNeverExpires(t *testing.T) {
 	}
 
 	// Find
-	sub3, err := g.Find(sub1.Id)
+	sub3, err := g.Find(ctx, sub1.Id)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -754,7 +785,7 @@ func TestSubscriptionAllFieldsWithTrialPeriodNeverExpires(t *testing.T) {
 	}
 
 	// Cancel
-	_, err = g.Cancel(sub1.Id)
+	_, err = g.Cancel(ctx, sub1.Id)
 	if err != nil {
 		t.Fatal(err)
 	}
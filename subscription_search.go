@@ -0,0 +1,64 @@
+package braintree
+
+// SubscriptionSearch builds a subscription search query from typed criteria, e.g.
+//
+//	s := new(SubscriptionSearch)
+//	s.Status().In(SubscriptionStatusPastDue)
+//	s.DaysPastDue().AtLeast(3)
+//	it, err := bt.Subscription().Search(ctx, s.Query())
+//
+// Criteria without a value are left out of the query.
+type SubscriptionSearch struct {
+	searchCriteria
+}
+
+func (s *SubscriptionSearch) Id() *TextCriterion {
+	return s.text("id")
+}
+
+// Ids matches any of the given subscription ids.
+func (s *SubscriptionSearch) Ids() *MultipleValueCriterion {
+	return s.multipleValue("ids")
+}
+
+// TransactionId matches subscriptions that created the transaction with the given id.
+func (s *SubscriptionSearch) TransactionId() *TextCriterion {
+	return s.text("transaction-id")
+}
+
+// Status matches the subscription status, see the SubscriptionStatus constants.
+func (s *SubscriptionSearch) Status() *MultipleValueCriterion {
+	return s.multipleValue("status")
+}
+
+func (s *SubscriptionSearch) PlanId() *MultipleValueCriterion {
+	return s.multipleValue("plan-id")
+}
+
+func (s *SubscriptionSearch) MerchantAccountId() *MultipleValueCriterion {
+	return s.multipleValue("merchant-account-id")
+}
+
+func (s *SubscriptionSearch) Price() *DecimalRangeCriterion {
+	return s.decimalRange("price")
+}
+
+func (s *SubscriptionSearch) DaysPastDue() *IntRangeCriterion {
+	return s.intRange("days-past-due")
+}
+
+func (s *SubscriptionSearch) BillingCyclesRemaining() *IntRangeCriterion {
+	return s.intRange("billing-cycles-remaining")
+}
+
+func (s *SubscriptionSearch) NextBillingDate() *DateRangeCriterion {
+	return s.dateRange("next-billing-date")
+}
+
+func (s *SubscriptionSearch) CreatedAt() *TimeRangeCriterion {
+	return s.timeRange("created-at")
+}
+
+func (s *SubscriptionSearch) InTrialPeriod() *BoolCriterion {
+	return s.boolean("in-trial-period")
+}
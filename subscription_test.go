@@ -0,0 +1,257 @@
+package braintree
+
+import (
+	"context"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/lionelbarrow/braintree-go/date"
+	"github.com/lionelbarrow/braintree-go/nullable"
+)
+
+func TestSubscriptionRetryCharge(t *testing.T) {
+	t.Parallel()
+
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/merchants/merchant-id/transactions" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		got = string(body)
+		writeXML(w, http.StatusCreated, `<transaction><id>abc123</id><status>submitted_for_settlement</status><subscription-id>sub1</subscription-id></transaction>`)
+	}))
+	defer srv.Close()
+
+	g := testServerGateway(srv).Subscription()
+	tx, err := g.RetryCharge(context.Background(), "sub1", NewDecimal(1000, 2), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `<transaction><type>sale</type><amount>10.00</amount><subscription-id>sub1</subscription-id><options><submit-for-settlement>true</submit-for-settlement></options></transaction>`
+	if got != want {
+		t.Fatalf("got request %s, want %s", got, want)
+	}
+	if tx.Id != "abc123" || tx.SubscriptionId != "sub1" || tx.Status != TransactionStatusSubmittedForSettlement {
+		t.Fatalf("got %+v", tx)
+	}
+}
+
+func TestSubscriptionRetryChargeError(t *testing.T) {
+	t.Parallel()
+
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		got = string(body)
+		writeXML(w, http.StatusUnprocessableEntity, `<api-error-response><errors><transaction><errors type="array"><error><code>91531</code><attribute>subscription_id</attribute><message>Subscription status must be Past Due in order to retry.</message></error></errors></transaction></errors><message>Subscription status must be Past Due in order to retry.</message></api-error-response>`)
+	}))
+	defer srv.Close()
+
+	g := testServerGateway(srv).Subscription()
+	_, err := g.RetryCharge(context.Background(), "sub1", nil, false)
+
+	if want := `<transaction><type>sale</type><subscription-id>sub1</subscription-id></transaction>`; got != want {
+		t.Fatalf("got request %s, want %s", got, want)
+	}
+	apiErr, ok := err.(*BraintreeError)
+	if !ok {
+		t.Fatalf("got %#v, want a *BraintreeError", err)
+	}
+	errs := apiErr.For("Transaction").On("SubscriptionId")
+	if len(errs) != 1 || errs[0].Code != ErrorCodeSubscriptionStatusMustBePastDue {
+		t.Fatalf("got %+v", errs)
+	}
+}
+
+func TestSubscriptionRequestModifications(t *testing.T) {
+	t.Parallel()
+
+	cycles := nullable.NewNullInt64(3, true)
+	neverExpires := nullable.NewNullBool(false, true)
+	sub := &SubscriptionRequest{
+		Id: "sub1",
+		AddOns: &ModificationsRequest{
+			Add: []AddModificationRequest{
+				{
+					InheritedFromId: "seat",
+					ModificationRequest: ModificationRequest{
+						Quantity:              5,
+						NumberOfBillingCycles: &cycles,
+						NeverExpires:          &neverExpires,
+					},
+				},
+			},
+			Update: []UpdateModificationRequest{
+				{
+					ExistingId:          "support",
+					ModificationRequest: ModificationRequest{Amount: NewDecimal(0, 2)},
+				},
+			},
+		},
+		Discounts: &ModificationsRequest{
+			RemoveExistingIds: []string{"launch", "loyalty"},
+		},
+	}
+
+	b, err := xml.MarshalIndent(sub, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `<subscription>
+  <id>sub1</id>
+  <add-ons>
+    <add type="array">
+      <modification>
+        <inherited-from-id>seat</inherited-from-id>
+        <quantity>5</quantity>
+        <number-of-billing-cycles>3</number-of-billing-cycles>
+        <never-expires>false</never-expires>
+      </modification>
+    </add>
+    <update type="array">
+      <modification>
+        <existing-id>support</existing-id>
+        <amount>0.00</amount>
+      </modification>
+    </update>
+  </add-ons>
+  <discounts>
+    <remove type="array">
+      <item>launch</item>
+      <item>loyalty</item>
+    </remove>
+  </discounts>
+</subscription>`
+	if got := string(b); got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestSubscriptionModificationsUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	body := `<subscription>
+  <id>sub1</id>
+  <add-ons type="array">
+    <add-on>
+      <id>seat</id>
+      <amount>10.00</amount>
+      <quantity type="integer">5</quantity>
+      <number-of-billing-cycles type="integer">3</number-of-billing-cycles>
+      <current-billing-cycle type="integer">1</current-billing-cycle>
+      <never-expires type="boolean">false</never-expires>
+      <kind>add_on</kind>
+    </add-on>
+  </add-ons>
+  <discounts type="array">
+    <discount>
+      <id>loyalty</id>
+      <amount>2.50</amount>
+      <quantity type="integer">1</quantity>
+      <number-of-billing-cycles nil="true"></number-of-billing-cycles>
+      <never-expires type="boolean">true</never-expires>
+      <kind>discount</kind>
+    </discount>
+  </discounts>
+</subscription>`
+
+	var sub Subscription
+	if err := xml.Unmarshal([]byte(body), &sub); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(sub.AddOns.AddOns) != 1 || len(sub.Discounts.Discounts) != 1 {
+		t.Fatalf("got %+v, %+v", sub.AddOns, sub.Discounts)
+	}
+	addOn := sub.AddOns.AddOns[0]
+	if addOn.Id != "seat" || addOn.Amount.Cmp(NewDecimal(1000, 2)) != 0 || addOn.Quantity != 5 ||
+		addOn.NumberOfBillingCycles != 3 || addOn.CurrentBillingCycle != 1 || addOn.Kind != ModificationKindAddOn {
+		t.Fatalf("got %+v", addOn)
+	}
+	discount := sub.Discounts.Discounts[0]
+	if discount.Id != "loyalty" || !discount.NeverExpires || discount.NumberOfBillingCycles != 0 {
+		t.Fatalf("got %+v", discount)
+	}
+}
+
+func TestSubscriptionUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	body := `<subscription>
+  <id>sub1</id>
+  <billing-period-start-date type="date">2016-09-11</billing-period-start-date>
+  <billing-period-end-date type="date">2016-10-10</billing-period-end-date>
+  <first-billing-date type="date">2016-08-11</first-billing-date>
+  <next-billing-date type="date">2016-10-11</next-billing-date>
+  <paid-through-date nil="true"></paid-through-date>
+  <days-past-due type="integer">3</days-past-due>
+  <failure-count type="integer">1</failure-count>
+  <status>Past Due</status>
+  <status-history type="array">
+    <status-event>
+      <timestamp type="datetime">2016-09-14T10:00:00Z</timestamp>
+      <status>Past Due</status>
+      <user nil="true"></user>
+      <subscription-source>recurring</subscription-source>
+      <balance>10.00</balance>
+      <price>10.00</price>
+      <currency-iso-code>USD</currency-iso-code>
+      <plan-id>test_plan</plan-id>
+    </status-event>
+    <status-event>
+      <timestamp type="datetime">2016-08-11T09:00:00Z</timestamp>
+      <status>Active</status>
+      <user>ops@example.com</user>
+      <subscription-source>api</subscription-source>
+      <balance>0.00</balance>
+      <price>10.00</price>
+      <currency-iso-code>USD</currency-iso-code>
+      <plan-id>test_plan</plan-id>
+    </status-event>
+  </status-history>
+</subscription>`
+
+	var sub Subscription
+	if err := xml.Unmarshal([]byte(body), &sub); err != nil {
+		t.Fatal(err)
+	}
+
+	dates := map[string]*date.Date{
+		"2016-09-11": sub.BillingPeriodStartDate,
+		"2016-10-10": sub.BillingPeriodEndDate,
+		"2016-08-11": sub.FirstBillingDate,
+		"2016-10-11": sub.NextBillingDate,
+	}
+	for want, d := range dates {
+		if d == nil || d.Format("2006-01-02") != want {
+			t.Errorf("got %v, want %s", d, want)
+		}
+	}
+	if sub.PaidThroughDate != nil && !sub.PaidThroughDate.IsZero() {
+		t.Errorf("got paid through date %v, want none", sub.PaidThroughDate)
+	}
+	if sub.DaysPastDue != 3 || sub.FailureCount != 1 {
+		t.Errorf("got days past due %d, failure count %d", sub.DaysPastDue, sub.FailureCount)
+	}
+
+	if len(sub.StatusHistory) != 2 {
+		t.Fatalf("got %d status events, want 2", len(sub.StatusHistory))
+	}
+	event := sub.StatusHistory[0]
+	if event.Status != SubscriptionStatusPastDue || event.SubscriptionSource != SubscriptionSourceRecurring ||
+		event.Balance.Cmp(NewDecimal(1000, 2)) != 0 || event.PlanId != "test_plan" ||
+		!event.Timestamp.Equal(time.Date(2016, time.September, 14, 10, 0, 0, 0, time.UTC)) {
+		t.Fatalf("got %+v", event)
+	}
+	if event := sub.StatusHistory[1]; event.Status != SubscriptionStatusActive || event.User != "ops@example.com" {
+		t.Fatalf("got %+v", event)
+	}
+}
@@ -8,6 +8,7 @@ var testCreditCards = map[string]CreditCard{
 	"visa":       CreditCard{Number: "4111111111111111"},
 	"mastercard": CreditCard{Number: "5555555555554444"},
 	"discover":   CreditCard{Number: "6011111111111117"},
+	"dispute":    CreditCard{Number: "4023898493988028"},
 }
 
 var testGateway = New(
@@ -3,99 +3,281 @@ package braintree
 import (
 	"time"
 
+	"github.com/lionelbarrow/braintree-go/date"
 	"github.com/lionelbarrow/braintree-go/nullable"
 )
 
+const (
+	TransactionStatusAuthorizationExpired   = "authorization_expired"
+	TransactionStatusAuthorizing            = "authorizing"
+	TransactionStatusAuthorized             = "authorized"
+	TransactionStatusGatewayRejected        = "gateway_rejected"
+	TransactionStatusFailed                 = "failed"
+	TransactionStatusProcessorDeclined      = "processor_declined"
+	TransactionStatusSettled                = "settled"
+	TransactionStatusSettlementConfirmed    = "settlement_confirmed"
+	TransactionStatusSettlementDeclined     = "settlement_declined"
+	TransactionStatusSettlementPending      = "settlement_pending"
+	TransactionStatusSettling               = "settling"
+	TransactionStatusSubmittedForSettlement = "submitted_for_settlement"
+	TransactionStatusVoided                 = "voided"
+)
+
+const (
+	TransactionTypeSale   = "sale"
+	TransactionTypeCredit = "credit"
+)
+
+const (
+	TransactionSourceAPI          = "api"
+	TransactionSourceControlPanel = "control_panel"
+	TransactionSourceRecurring    = "recurring"
+)
+
+const (
+	GatewayRejectionReasonApplicationIncomplete = "application_incomplete"
+	GatewayRejectionReasonAVS                   = "avs"
+	GatewayRejectionReasonAVSAndCVV             = "avs_and_cvv"
+	GatewayRejectionReasonCVV                   = "cvv"
+	GatewayRejectionReasonDuplicate             = "duplicate"
+	GatewayRejectionReasonFraud                 = "fraud"
+	GatewayRejectionReasonRiskThreshold         = "risk_threshold"
+	GatewayRejectionReasonThreeDSecure          = "three_d_secure"
+	GatewayRejectionReasonTokenIssuance         = "token_issuance"
+)
+
+// Codes of AVSPostalCodeResponseCode and AVSStreetAddressResponseCode. CVVResponseCode
+// uses the same codes, see also CVVResponseCodeIssuerDoesNotParticipate.
+const (
+	// AVSResponseCodeMatches is returned when the value matches the issuer's records.
+	AVSResponseCodeMatches = "M"
+	// AVSResponseCodeDoesNotMatch is returned when the value does not match.
+	AVSResponseCodeDoesNotMatch = "N"
+	// AVSResponseCodeNotVerified is returned when the issuer did not verify the value.
+	AVSResponseCodeNotVerified = "U"
+	// AVSResponseCodeNotProvided is returned when no value was sent.
+	AVSResponseCodeNotProvided = "I"
+	// AVSResponseCodeNotApplicable is returned when verification was not attempted.
+	AVSResponseCodeNotApplicable = "A"
+	// AVSResponseCodeSkipped is returned when verification was skipped by the merchant's rules.
+	AVSResponseCodeSkipped = "B"
+)
+
+// Codes of AVSErrorResponseCode, set when address verification could not be performed.
+const (
+	// AVSErrorResponseCodeIssuerDoesNotParticipate is returned when the issuer does not support AVS.
+	AVSErrorResponseCodeIssuerDoesNotParticipate = "S"
+	// AVSErrorResponseCodeSystemError is returned when the AVS system failed.
+	AVSErrorResponseCodeSystemError = "E"
+)
+
+// CVVResponseCodeIssuerDoesNotParticipate is the CVVResponseCode returned when the
+// issuer does not support CVV verification.
+const CVVResponseCodeIssuerDoesNotParticipate = "S"
+
+// EscrowStatus is the state of the funds of a sub-merchant transaction held in escrow.
+type EscrowStatus string
+
+const (
+	EscrowStatusHoldPending    EscrowStatus = "hold_pending"
+	EscrowStatusHeld           EscrowStatus = "held"
+	EscrowStatusReleasePending EscrowStatus = "release_pending"
+	EscrowStatusReleased       EscrowStatus = "released"
+	EscrowStatusRefunded       EscrowStatus = "refunded"
+)
+
 type Transaction struct {
-	XMLName                     string               `xml:"transaction"`
-	Id                          string               `xml:"id,omitempty"`
-	CustomerID                  string               `xml:"customer-id,omitempty"`
-	Status                      string               `xml:"status,omitempty"`
-	Type                        string               `xml:"type,omitempty"`
-	Amount                      *Decimal             `xml:"amount"`
-	OrderId                     string               `xml:"order-id,omitempty"`
-	PaymentMethodToken          string               `xml:"payment-method-token,omitempty"`
-	PaymentMethodNonce          string               `xml:"payment-method-nonce,omitempty"`
-	MerchantAccountId           string               `xml:"merchant-account-id,omitempty"`
-	PlanId                      string               `xml:"plan-id,omitempty"`
-	CreditCard                  *CreditCard          `xml:"credit-card,omitempty"`
-	Customer                    *Customer            `xml:"customer,omitempty"`
-	BillingAddress              *Address             `xml:"billing,omitempty"`
-	ShippingAddress             *Address             `xml:"shipping,omitempty"`
-	DeviceData                  string               `xml:"device-data,omitempty"`
-	Options                     *TransactionOptions  `xml:"options,omitempty"`
-	ServiceFeeAmount            *Decimal             `xml:"service-fee-amount,attr,omitempty"`
-	CreatedAt                   *time.Time           `xml:"created-at,omitempty"`
-	UpdatedAt                   *time.Time           `xml:"updated-at,omitempty"`
-	DisbursementDetails         *DisbursementDetails `xml:"disbursement-details,omitempty"`
-	RefundId                    string               `xml:"refund-id,omitempty"`
-	RefundIds                   *[]string            `xml:"refund-ids>item,omitempty"`
-	RefundedTransactionId       *string              `xml:"refunded-transaction-id,omitempty"`
-	ProcessorResponseCode       int                  `xml:"processor-response-code,omitempty"`
-	ProcessorResponseText       string               `xml:"processor-response-text,omitempty"`
-	ProcessorAuthorizationCode  string               `xml:"processor-authorization-code,omitempty"`
-	SettlementBatchId           string               `xml:"settlement-batch-id,omitempty"`
-	PaymentInstrumentType       string               `xml:"payment-instrument-type,omitempty"`
-	PayPalDetails               *PayPalDetails       `xml:"paypal,omitempty"`
-	AdditionalProcessorResponse string               `xml:"additional-processor-response,omitempty"`
-	RiskData                    *RiskData            `xml:"risk-data,omitempty"`
-	Descriptor                  *Descriptor          `xml:"descriptor,omitempty"`
-}
-
-// TODO: not all transaction fields are implemented yet, here are the missing fields (add on demand)
-//
-// <transaction>
-//   <currency-iso-code>USD</currency-iso-code>
-//   <custom-fields>
-//   </custom-fields>
-//   <avs-error-response-code nil="true"></avs-error-response-code>
-//   <avs-postal-code-response-code>I</avs-postal-code-response-code>
-//   <avs-street-address-response-code>I</avs-street-address-response-code>
-//   <cvv-response-code>I</cvv-response-code>
-//   <gateway-rejection-reason nil="true"></gateway-rejection-reason>
-//   <voice-referral-number nil="true"></voice-referral-number>
-//   <purchase-order-number nil="true"></purchase-order-number>
-//   <tax-amount nil="true"></tax-amount>
-//   <tax-exempt type="boolean">false</tax-exempt>
-//   <status-history type="array">
-//     <status-event>
-//       <timestamp type="datetime">2013-10-07T17:26:14Z</timestamp>
-//       <status>authorized</status>
-//       <amount>7.00</amount>
-//       <user>eaigner</user>
-//       <transaction-source>Recurring</transaction-source>
-//     </status-event>
-//     <status-event>
-//       <timestamp type="datetime">2013-10-07T17:26:14Z</timestamp>
-//       <status>submitted_for_settlement</status>
-//       <amount>7.00</amount>
-//       <user>eaigner</user>
-//       <transaction-source>Recurring</transaction-source>
-//     </status-event>
-//     <status-event>
-//       <timestamp type="datetime">2013-10-08T07:06:38Z</timestamp>
-//       <status>settled</status>
-//       <amount>7.00</amount>
-//       <user nil="true"></user>
-//       <transaction-source></transaction-source>
-//     </status-event>
-//   </status-history>
-//   <plan-id>bronze</plan-id>
-//   <subscription-id>jqsydb</subscription-id>
-//   <subscription>
-//     <billing-period-end-date type="date">2013-11-06</billing-period-end-date>
-//     <billing-period-start-date type="date">2013-10-07</billing-period-start-date>
-//   </subscription>
-//   <add-ons type="array"/>
-//   <discounts type="array"/>
-//   <descriptor>
-//     <name nil="true"></name>
-//     <phone nil="true"></phone>
-//   </descriptor>
-//   <recurring type="boolean">true</recurring>
-//   <channel nil="true"></channel>
-//   <escrow-status nil="true"></escrow-status>
-// </transaction>
+	XMLName                         string                    `xml:"transaction"`
+	Id                              string                    `xml:"id,omitempty"`
+	CustomerID                      string                    `xml:"customer-id,omitempty"`
+	Status                          string                    `xml:"status,omitempty"`
+	Type                            string                    `xml:"type,omitempty"`
+	Amount                          *Decimal                  `xml:"amount"`
+	OrderId                         string                    `xml:"order-id,omitempty"`
+	PaymentMethodToken              string                    `xml:"payment-method-token,omitempty"`
+	PaymentMethodNonce              string                    `xml:"payment-method-nonce,omitempty"`
+	MerchantAccountId               string                    `xml:"merchant-account-id,omitempty"`
+	PlanId                          string                    `xml:"plan-id,omitempty"`
+	SubscriptionId                  string                    `xml:"subscription-id,omitempty"`
+	CreditCard                      *CreditCard               `xml:"credit-card,omitempty"`
+	Customer                        *Customer                 `xml:"customer,omitempty"`
+	BillingAddress                  *Address                  `xml:"billing,omitempty"`
+	ShippingAddress                 *Address                  `xml:"shipping,omitempty"`
+	DeviceData                      string                    `xml:"device-data,omitempty"`
+	Options                         *TransactionOptions       `xml:"options,omitempty"`
+	ServiceFeeAmount                *Decimal                  `xml:"service-fee-amount,attr,omitempty"`
+	CreatedAt                       *time.Time                `xml:"created-at,omitempty"`
+	UpdatedAt                       *time.Time                `xml:"updated-at,omitempty"`
+	DisbursementDetails             *DisbursementDetails      `xml:"disbursement-details,omitempty"`
+	RefundId                        string                    `xml:"refund-id,omitempty"`
+	RefundIds                       *[]string                 `xml:"refund-ids>item,omitempty"`
+	RefundedTransactionId           *string                   `xml:"refunded-transaction-id,omitempty"`
+	AuthorizedTransactionId         string                    `xml:"authorized-transaction-id,omitempty"`
+	PartialSettlementTransactionIds *[]string                 `xml:"partial-settlement-transaction-ids>item,omitempty"`
+	ProcessorResponseCode           ProcessorResponseCode     `xml:"processor-response-code,omitempty"`
+	ProcessorResponseText           string                    `xml:"processor-response-text,omitempty"`
+	ProcessorAuthorizationCode      string                    `xml:"processor-authorization-code,omitempty"`
+	SettlementBatchId               string                    `xml:"settlement-batch-id,omitempty"`
+	PaymentInstrumentType           string                    `xml:"payment-instrument-type,omitempty"`
+	PayPalDetails                   *PayPalDetails            `xml:"paypal,omitempty"`
+	AdditionalProcessorResponse     string                    `xml:"additional-processor-response,omitempty"`
+	RiskData                        *RiskData                 `xml:"risk-data,omitempty"`
+	Descriptor                      *Descriptor               `xml:"descriptor,omitempty"`
+	CurrencyISOCode                 string                    `xml:"currency-iso-code,omitempty"`
+	AVSErrorResponseCode            string                    `xml:"avs-error-response-code,omitempty"`
+	AVSPostalCodeResponseCode       string                    `xml:"avs-postal-code-response-code,omitempty"`
+	AVSStreetAddressResponseCode    string                    `xml:"avs-street-address-response-code,omitempty"`
+	CVVResponseCode                 string                    `xml:"cvv-response-code,omitempty"`
+	GatewayRejectionReason          string                    `xml:"gateway-rejection-reason,omitempty"`
+	VoiceReferralNumber             string                    `xml:"voice-referral-number,omitempty"`
+	PurchaseOrderNumber             string                    `xml:"purchase-order-number,omitempty"`
+	TaxAmount                       *Decimal                  `xml:"tax-amount,omitempty"`
+	TaxExempt                       bool                      `xml:"tax-exempt,omitempty"`
+	ShippingAmount                  *Decimal                  `xml:"shipping-amount,omitempty"`
+	DiscountAmount                  *Decimal                  `xml:"discount-amount,omitempty"`
+	ShipsFromPostalCode             string                    `xml:"ships-from-postal-code,omitempty"`
+	LineItems                       *TransactionLineItems     `xml:"line-items,omitempty"`
+	StatusHistory                   *TransactionStatusHistory `xml:"status-history,omitempty"`
+	SubscriptionDetails             *SubscriptionDetails      `xml:"subscription,omitempty"`
+	AddOns                          *AddOnList                `xml:"add-ons,omitempty"`
+	Discounts                       *DiscountList             `xml:"discounts,omitempty"`
+	Recurring                       bool                      `xml:"recurring,omitempty"`
+	Channel                         string                    `xml:"channel,omitempty"`
+	EscrowStatus                    EscrowStatus              `xml:"escrow-status,omitempty"`
+	CustomFields                    CustomFields              `xml:"custom-fields,omitempty"`
+	Disputes                        *DisputeList              `xml:"disputes,omitempty"`
+}
+
+// IdempotencyKey returns the order id, which allows creating the transaction to be
+// retried when RetryPolicy.RetryMutating is set.
+func (t *Transaction) IdempotencyKey() string {
+	return t.OrderId
+}
+
+// ResponseCategory classifies the outcome of the transaction. Transactions rejected
+// by the gateway are classified by their GatewayRejectionReason, all others by their
+// ProcessorResponseCode.
+func (t *Transaction) ResponseCategory() ResponseCategory {
+	if t.Status == TransactionStatusGatewayRejected {
+		switch t.GatewayRejectionReason {
+		case GatewayRejectionReasonApplicationIncomplete, GatewayRejectionReasonDuplicate, GatewayRejectionReasonTokenIssuance:
+			return ResponseCategoryValidation
+		case "":
+			return ResponseCategoryUnknown
+		}
+		return ResponseCategoryFraud
+	}
+	return t.ProcessorResponseCode.Category()
+}
+
+// CapturableAmount returns how much of the authorization remains to be captured with
+// partial settlements, given the transactions listed in PartialSettlementTransactionIds, e.g.
+// as returned by SubmitForPartialSettlement or Find. Partial settlements that were
+// voided or declined are not counted, nor are transactions of other authorizations.
+// It returns nil if the transaction has no amount.
+func (t *Transaction) CapturableAmount(partialSettlements []*Transaction) *Decimal {
+	if t.Amount == nil {
+		return nil
+	}
+	switch t.Status {
+	case TransactionStatusAuthorized, TransactionStatusSettlementPending:
+	default:
+		return NewDecimal(0, t.Amount.Scale)
+	}
+	var captured []*Decimal
+	for _, p := range partialSettlements {
+		if p.AuthorizedTransactionId != t.Id {
+			continue
+		}
+		switch p.Status {
+		case TransactionStatusVoided, TransactionStatusProcessorDeclined, TransactionStatusGatewayRejected,
+			TransactionStatusFailed, TransactionStatusSettlementDeclined:
+			continue
+		}
+		captured = append(captured, p.Amount)
+	}
+	return remainingAmount(t.Amount, captured)
+}
+
+// RefundableAmount returns how much of the transaction remains to be refunded, given
+// the refunds listed in RefundIds, e.g. as returned by Refund or Find. Refunds that
+// failed or were voided are not counted, nor are refunds of other transactions. Only
+// sales that are settling or settled can be refunded, for others it returns zero.
+// It returns nil if the transaction has no amount.
+func (t *Transaction) RefundableAmount(refunds []*Transaction) *Decimal {
+	if t.Amount == nil {
+		return nil
+	}
+	if t.Type != TransactionTypeSale {
+		return NewDecimal(0, t.Amount.Scale)
+	}
+	switch t.Status {
+	case TransactionStatusSettled, TransactionStatusSettling, TransactionStatusSettlementConfirmed, TransactionStatusSettlementPending:
+	default:
+		return NewDecimal(0, t.Amount.Scale)
+	}
+	var refunded []*Decimal
+	for _, r := range refunds {
+		if r.RefundedTransactionId == nil || *r.RefundedTransactionId != t.Id {
+			continue
+		}
+		switch r.Status {
+		case TransactionStatusVoided, TransactionStatusProcessorDeclined, TransactionStatusGatewayRejected,
+			TransactionStatusFailed, TransactionStatusSettlementDeclined:
+			continue
+		}
+		refunded = append(refunded, r.Amount)
+	}
+	return remainingAmount(t.Amount, refunded)
+}
+
+// remainingAmount returns amount less the sum of used, at the largest scale of the
+// amounts and never less than zero.
+func remainingAmount(amount *Decimal, used []*Decimal) *Decimal {
+	scale := amount.Scale
+	for _, u := range used {
+		if u != nil && u.Scale > scale {
+			scale = u.Scale
+		}
+	}
+	rescale := func(d *Decimal) int64 {
+		v := d.Unscaled
+		for i := d.Scale; i < scale; i++ {
+			v *= 10
+		}
+		return v
+	}
+	remaining := rescale(amount)
+	for _, u := range used {
+		if u != nil {
+			remaining -= rescale(u)
+		}
+	}
+	if remaining < 0 {
+		remaining = 0
+	}
+	return NewDecimal(remaining, scale)
+}
+
+type TransactionStatusHistory struct {
+	Events []*TransactionStatusEvent `xml:"status-event"`
+}
+
+// TransactionStatusEvent records a change of a transaction's status.
+type TransactionStatusEvent struct {
+	Timestamp *time.Time `xml:"timestamp"`
+	Status    string     `xml:"status"`
+	Amount    *Decimal   `xml:"amount"`
+	User      string     `xml:"user"`
+	// TransactionSource is what caused the change, see the TransactionSource constants.
+	TransactionSource string `xml:"transaction-source"`
+}
+
+// SubscriptionDetails holds the billing period a subscription transaction pays for.
+type SubscriptionDetails struct {
+	BillingPeriodStartDate *date.Date `xml:"billing-period-start-date,omitempty"`
+	BillingPeriodEndDate   *date.Date `xml:"billing-period-end-date,omitempty"`
+}
 
 type Transactions struct {
 	Transaction []*Transaction `xml:"transaction"`
@@ -106,6 +288,44 @@ type TransactionOptions struct {
 	StoreInVault                     bool `xml:"store-in-vault,omitempty"`
 	AddBillingAddressToPaymentMethod bool `xml:"add-billing-address-to-payment-method,omitempty"`
 	StoreShippingAddressInVault      bool `xml:"store-shipping-address-in-vault,omitempty"`
+	// HoldInEscrow holds the funds of a sub-merchant transaction in escrow once it
+	// settles. The transaction's MerchantAccountId must be that of a sub-merchant.
+	HoldInEscrow bool `xml:"hold-in-escrow,omitempty"`
+}
+
+// RefundRequest refunds a settled transaction. If Amount is nil the remaining amount
+// of the transaction is refunded.
+type RefundRequest struct {
+	XMLName string   `xml:"transaction"`
+	Amount  *Decimal `xml:"amount,omitempty"`
+	// OrderId identifies the refund in the merchant's own records.
+	OrderId string `xml:"order-id,omitempty"`
+	// MerchantAccountId is the merchant account the refund is made through, if not
+	// that of the refunded transaction.
+	MerchantAccountId string `xml:"merchant-account-id,omitempty"`
+}
+
+// TransactionCloneRequest creates a new transaction with the payment method, customer
+// and addresses of an existing one.
+type TransactionCloneRequest struct {
+	XMLName string                   `xml:"transaction-clone"`
+	Amount  *Decimal                 `xml:"amount"`
+	Channel string                   `xml:"channel,omitempty"`
+	Options *TransactionCloneOptions `xml:"options,omitempty"`
+}
+
+type TransactionCloneOptions struct {
+	SubmitForSettlement bool `xml:"submit-for-settlement"`
+}
+
+// TransactionUpdateDetailsRequest corrects a transaction that is submitted for
+// settlement. Unset fields are left unchanged, and the amount cannot be raised above
+// the authorized amount.
+type TransactionUpdateDetailsRequest struct {
+	XMLName    string      `xml:"transaction"`
+	Amount     *Decimal    `xml:"amount,omitempty"`
+	OrderId    string      `xml:"order-id,omitempty"`
+	Descriptor *Descriptor `xml:"descriptor,omitempty"`
 }
 
 type TransactionSearchResult struct {
@@ -1,7 +1,9 @@
 package braintree
 
 import (
+	"context"
 	"encoding/xml"
+	"errors"
 	"fmt"
 )
 
@@ -10,8 +12,11 @@ type TransactionGateway struct {
 }
 
 // Create initiates a transaction.
-func (g *TransactionGateway) Create(tx *Transaction) (*Transaction, error) {
-	resp, err := g.execute("POST", "transactions", tx)
+func (g *TransactionGateway) Create(ctx context.Context, tx *Transaction) (*Transaction, error) {
+	if tx.Options != nil && tx.Options.HoldInEscrow && tx.MerchantAccountId == "" {
+		return nil, errors.New("braintree: holding in escrow requires the MerchantAccountId of a sub-merchant account")
+	}
+	resp, err := g.execute(ctx, "Transaction", "POST", "transactions", tx)
 	if err != nil {
 		return nil, err
 	}
@@ -24,14 +29,57 @@ func (g *TransactionGateway) Create(tx *Transaction) (*Transaction, error) {
 
 // SubmitForSettlement submits the transaction with the specified id for settlement.
 // If the amount is omitted, the full amount is settled.
-func (g *TransactionGateway) SubmitForSettlement(id string, amount ...*Decimal) (*Transaction, error) {
+func (g *TransactionGateway) SubmitForSettlement(ctx context.Context, id string, amount ...*Decimal) (*Transaction, error) {
 	var tx *Transaction
 	if len(amount) > 0 {
 		tx = &Transaction{
 			Amount: amount[0],
 		}
 	}
-	resp, err := g.execute("PUT", "transactions/"+id+"/submit_for_settlement", tx)
+	resp, err := g.execute(ctx, "Transaction", "PUT", "transactions/"+id+"/submit_for_settlement", tx)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case 200:
+		return resp.transaction()
+	}
+	return nil, &invalidResponseError{resp}
+}
+
+// SubmitForPartialSettlement captures part of the authorization with the specified id,
+// returning the child transaction created for the amount. An authorization can be
+// partially settled several times, see Transaction.CapturableAmount.
+func (g *TransactionGateway) SubmitForPartialSettlement(ctx context.Context, id string, amount *Decimal) (*Transaction, error) {
+	resp, err := g.execute(ctx, "Transaction", "POST", "transactions/"+id+"/submit_for_partial_settlement", &Transaction{Amount: amount})
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case 201:
+		return resp.transaction()
+	}
+	return nil, &invalidResponseError{resp}
+}
+
+// Clone creates a new transaction from the one with the specified id, charging the
+// same payment method the amount of the request.
+func (g *TransactionGateway) Clone(ctx context.Context, id string, req *TransactionCloneRequest) (*Transaction, error) {
+	resp, err := g.execute(ctx, "Transaction", "POST", "transactions/"+id+"/clone", req)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case 201:
+		return resp.transaction()
+	}
+	return nil, &invalidResponseError{resp}
+}
+
+// UpdateDetails changes the amount, order id or descriptor of the transaction with
+// the specified id, which must be submitted for settlement.
+func (g *TransactionGateway) UpdateDetails(ctx context.Context, id string, req *TransactionUpdateDetailsRequest) (*Transaction, error) {
+	resp, err := g.execute(ctx, "Transaction", "PUT", "transactions/"+id+"/update_details", req)
 	if err != nil {
 		return nil, err
 	}
@@ -44,9 +92,9 @@ func (g *TransactionGateway) SubmitForSettlement(id string, amount ...*Decimal)
 
 // Settle settles a transaction.
 // This action is only available in the sandbox environment.
-func (g *TransactionGateway) Settle(id string) (*Transaction, error) {
+func (g *TransactionGateway) Settle(ctx context.Context, id string) (*Transaction, error) {
 	if g.Environment != Production {
-		resp, err := g.execute("PUT", "transactions/"+id+"/settle", nil)
+		resp, err := g.execute(ctx, "Transaction", "PUT", "transactions/"+id+"/settle", nil)
 		if err != nil {
 			return nil, err
 		}
@@ -63,9 +111,47 @@ func (g *TransactionGateway) Settle(id string) (*Transaction, error) {
 // Void voids the transaction with the specified id if it has a status of authorized or
 // submitted_for_settlement. When the transaction is voided Braintree will do an authorization
 // reversal if possible so that the customer won’t have a pending charge on their card
-func (g *TransactionGateway) Void(id string) (*Transaction, error) {
-	resp, err := g.execute("PUT", "transactions/"+id+"/void", nil)
+func (g *TransactionGateway) Void(ctx context.Context, id string) (*Transaction, error) {
+	resp, err := g.execute(ctx, "Transaction", "PUT", "transactions/"+id+"/void", nil)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case 200:
+		return resp.transaction()
+	}
+	return nil, &invalidResponseError{resp}
+}
+
+// HoldInEscrow holds the funds of the sub-merchant transaction with the specified id
+// in escrow once it settles. It fails with an *EscrowError if the transaction is not
+// on a sub-merchant account.
+func (g *TransactionGateway) HoldInEscrow(ctx context.Context, id string) (*Transaction, error) {
+	return g.escrow(ctx, id, "hold_in_escrow", ErrorCodeTransactionCannotHoldInEscrow)
+}
+
+// ReleaseFromEscrow submits the transaction with the specified id, which must be held
+// in escrow, for release to the sub-merchant.
+func (g *TransactionGateway) ReleaseFromEscrow(ctx context.Context, id string) (*Transaction, error) {
+	return g.escrow(ctx, id, "release_from_escrow", ErrorCodeTransactionCannotReleaseFromEscrow)
+}
+
+// CancelRelease cancels a pending release from escrow of the transaction with the
+// specified id, keeping its funds held.
+func (g *TransactionGateway) CancelRelease(ctx context.Context, id string) (*Transaction, error) {
+	return g.escrow(ctx, id, "cancel_release", ErrorCodeTransactionCannotCancelRelease)
+}
+
+func (g *TransactionGateway) escrow(ctx context.Context, id, action string, code ValidationErrorCode) (*Transaction, error) {
+	resp, err := g.execute(ctx, "Transaction", "PUT", "transactions/"+id+"/"+action, nil)
 	if err != nil {
+		if bte, ok := err.(*BraintreeError); ok {
+			for _, e := range bte.All() {
+				if e.Code == code {
+					return nil, &EscrowError{TransactionId: id, Code: code, Err: bte}
+				}
+			}
+		}
 		return nil, err
 	}
 	switch resp.StatusCode {
@@ -78,14 +164,15 @@ func (g *TransactionGateway) Void(id string) (*Transaction, error) {
 // A transaction can be refunded if it is settled or settling.
 // If the transaction has not yet begun settlement, use Void() instead.
 // If you do not specify an amount to refund, the entire transaction amount will be refunded.
-func (g *TransactionGateway) Refund(id string, amount ...*Decimal) (*Transaction, error) {
+// Use RefundWithRequest to set the order id of the refund.
+func (g *TransactionGateway) Refund(ctx context.Context, id string, amount ...*Decimal) (*Transaction, error) {
 	var tx *Transaction
 	if len(amount) > 0 {
 		tx = &Transaction{
 			Amount: amount[0],
 		}
 	}
-	resp, err := g.execute("POST", "transactions/"+id+"/refund", tx)
+	resp, err := g.execute(ctx, "Transaction", "POST", "transactions/"+id+"/refund", tx)
 	if err != nil {
 		return nil, err
 	}
@@ -98,9 +185,52 @@ func (g *TransactionGateway) Refund(id string, amount ...*Decimal) (*Transaction
 	return nil, &invalidResponseError{resp}
 }
 
+// RefundWithRequest refunds the transaction with the specified id, which must be
+// settled or settling. Unlike Refund it accepts the refund's order id.
+func (g *TransactionGateway) RefundWithRequest(ctx context.Context, id string, req *RefundRequest) (*Transaction, error) {
+	resp, err := g.execute(ctx, "Transaction", "POST", "transactions/"+id+"/refund", req)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case 200, 201:
+		return resp.transaction()
+	}
+	return nil, &invalidResponseError{resp}
+}
+
+// RefundableAmount returns how much of tx remains to be refunded, fetching the refunds
+// listed in its RefundIds with SearchAll. See Transaction.RefundableAmount.
+func (g *TransactionGateway) RefundableAmount(ctx context.Context, tx *Transaction) (*Decimal, error) {
+	if tx.RefundIds == nil || len(*tx.RefundIds) == 0 {
+		return tx.RefundableAmount(nil), nil
+	}
+	it, err := g.SearchAll(ctx, idsQuery(*tx.RefundIds))
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+	var refunds []*Transaction
+	found := make(map[string]bool, len(*tx.RefundIds))
+	for it.Next() {
+		refund := it.Transaction()
+		refunds = append(refunds, refund)
+		found[refund.Id] = true
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	for _, id := range *tx.RefundIds {
+		if !found[id] {
+			return nil, fmt.Errorf("refund %q of transaction %q not found", id, tx.Id)
+		}
+	}
+	return tx.RefundableAmount(refunds), nil
+}
+
 // Find finds the transaction with the specified id.
-func (g *TransactionGateway) Find(id string) (*Transaction, error) {
-	resp, err := g.execute("GET", "transactions/"+id, nil)
+func (g *TransactionGateway) Find(ctx context.Context, id string) (*Transaction, error) {
+	resp, err := g.execute(ctx, "Transaction", "GET", "transactions/"+id, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -111,9 +241,28 @@ func (g *TransactionGateway) Find(id string) (*Transaction, error) {
 	return nil, &invalidResponseError{resp}
 }
 
-// Search finds all transactions matching the search query.
-func (g *TransactionGateway) Search(query *SearchQuery) (*TransactionSearchResult, error) {
-	resp, err := g.execute("POST", "transactions/advanced_search", query)
+// LineItems returns the Level 3 line items of the transaction with the specified id.
+func (g *TransactionGateway) LineItems(ctx context.Context, id string) ([]*TransactionLineItem, error) {
+	resp, err := g.execute(ctx, "Transaction", "GET", "transactions/"+id+"/line_items", nil)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case 200:
+		return resp.lineItems()
+	}
+	return nil, &invalidResponseError{resp}
+}
+
+// Search finds the transactions matching the search query. Only the first page of
+// results is returned, use SearchAll to walk all of them.
+func (g *TransactionGateway) Search(ctx context.Context, query *SearchQuery) (*TransactionSearchResult, error) {
+	return g.search(ctx, "Transaction", query)
+}
+
+// search runs a transaction search on behalf of the named gateway.
+func (g *TransactionGateway) search(ctx context.Context, gateway string, query *SearchQuery) (*TransactionSearchResult, error) {
+	resp, err := g.execute(ctx, gateway, "POST", "transactions/advanced_search", query)
 	if err != nil {
 		return nil, err
 	}
@@ -125,6 +274,27 @@ func (g *TransactionGateway) Search(query *SearchQuery) (*TransactionSearchResul
 	return &v, err
 }
 
+// SearchAll finds all transactions matching the search query. Unlike Search it is
+// not limited to a single page of results: the returned iterator fetches further
+// pages as it advances.
+func (g *TransactionGateway) SearchAll(ctx context.Context, query *SearchQuery) (*TransactionIterator, error) {
+	it, err := newSearchIterator(ctx, g.Braintree, "Transaction", "transactions", query, func(ctx context.Context, ids []string) ([]interface{}, error) {
+		result, err := g.Search(ctx, idsQuery(ids))
+		if err != nil {
+			return nil, err
+		}
+		items := make([]interface{}, len(result.Transactions))
+		for i, tx := range result.Transactions {
+			items[i] = tx
+		}
+		return items, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &TransactionIterator{it}, nil
+}
+
 type testOperationPerformedInProductionError struct {
 	error
 }
@@ -132,3 +302,22 @@ type testOperationPerformedInProductionError struct {
 func (e *testOperationPerformedInProductionError) Error() string {
 	return fmt.Sprint("Operation not allowed in production environment")
 }
+
+// EscrowError is returned by the escrow operations when the gateway refuses to change
+// the escrow status of a transaction. Only transactions on sub-merchant accounts can
+// be held in escrow, only held transactions can be released, and only pending
+// releases can be cancelled.
+type EscrowError struct {
+	TransactionId string
+	// Code is the gateway's validation error code, e.g. ErrorCodeTransactionCannotHoldInEscrow.
+	Code ValidationErrorCode
+	Err  *BraintreeError
+}
+
+func (e *EscrowError) Error() string {
+	return fmt.Sprintf("braintree: transaction %s: %s", e.TransactionId, e.Code.Message())
+}
+
+func (e *EscrowError) Unwrap() error {
+	return e.Err
+}
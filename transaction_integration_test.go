@@ -1,6 +1,8 @@
 package braintree
 
 import (
+	"context"
+	"errors"
 	"math/rand"
 	"testing"
 	"time"
@@ -15,7 +17,9 @@ func randomAmount() *Decimal {
 func TestTransactionCreateSubmitForSettlementAndVoid(t *testing.T) {
 	t.Parallel()
 
-	tx, err := testGateway.Transaction().Create(&Transaction{
+	ctx := context.Background()
+
+	tx, err := testGateway.Transaction().Create(ctx, &Transaction{
 		Type:   "sale",
 		Amount: NewDecimal(2000, 2),
 		CreditCard: &CreditCard{
@@ -38,7 +42,7 @@ func TestTransactionCreateSubmitForSettlementAndVoid(t *testing.T) {
 
 	// Submit for settlement
 	ten := NewDecimal(1000, 2)
-	tx2, err := testGateway.Transaction().SubmitForSettlement(tx.Id, ten)
+	tx2, err := testGateway.Transaction().SubmitForSettlement(ctx, tx.Id, ten)
 
 	t.Log(tx2)
 
@@ -53,7 +57,7 @@ func TestTransactionCreateSubmitForSettlementAndVoid(t *testing.T) {
 	}
 
 	// Void
-	tx3, err := testGateway.Transaction().Void(tx2.Id)
+	tx3, err := testGateway.Transaction().Void(ctx, tx2.Id)
 
 	t.Log(tx3)
 
@@ -68,9 +72,11 @@ func TestTransactionCreateSubmitForSettlementAndVoid(t *testing.T) {
 func TestTransactionSearch(t *testing.T) {
 	t.Parallel()
 
+	ctx := context.Background()
+
 	txg := testGateway.Transaction()
 	createTx := func(amount *Decimal, customerName string) error {
-		_, err := txg.Create(&Transaction{
+		_, err := txg.Create(ctx, &Transaction{
 			Type:   "sale",
 			Amount: amount,
 			Customer: &Customer{
@@ -100,7 +106,7 @@ func TestTransactionSearch(t *testing.T) {
 	f := query.AddTextField("customer-first-name")
 	f.Is = name0
 
-	result, err := txg.Search(query)
+	result, err := txg.Search(ctx, query)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -117,9 +123,11 @@ func TestTransactionSearch(t *testing.T) {
 }
 
 func TestTransactionSearchTime(t *testing.T) {
+	ctx := context.Background()
+
 	txg := testGateway.Transaction()
 	createTx := func(amount *Decimal, customerName string) error {
-		_, err := txg.Create(&Transaction{
+		_, err := txg.Create(ctx, &Transaction{
 			Type:   "sale",
 			Amount: amount,
 			Customer: &Customer{
@@ -152,7 +160,7 @@ func TestTransactionSearchTime(t *testing.T) {
 		f2 := query.AddTimeField("created-at")
 		f2.Max = time.Now()
 
-		result, err := txg.Search(query)
+		result, err := txg.Search(ctx, query)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -175,7 +183,7 @@ func TestTransactionSearchTime(t *testing.T) {
 		f2 := query.AddTimeField("created-at")
 		f2.Max = time.Now().Add(-time.Hour)
 
-		result, err := txg.Search(query)
+		result, err := txg.Search(ctx, query)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -190,7 +198,9 @@ func TestTransactionSearchTime(t *testing.T) {
 func TestTransactionCreateWhenGatewayRejected(t *testing.T) {
 	t.Parallel()
 
-	_, err := testGateway.Transaction().Create(&Transaction{
+	ctx := context.Background()
+
+	_, err := testGateway.Transaction().Create(ctx, &Transaction{
 		Type:   "sale",
 		Amount: NewDecimal(201000, 2),
 		CreditCard: &CreditCard{
@@ -216,7 +226,9 @@ func TestTransactionCreateWhenGatewayRejected(t *testing.T) {
 func TestFindTransaction(t *testing.T) {
 	t.Parallel()
 
-	createdTransaction, err := testGateway.Transaction().Create(&Transaction{
+	ctx := context.Background()
+
+	createdTransaction, err := testGateway.Transaction().Create(ctx, &Transaction{
 		Type:   "sale",
 		Amount: randomAmount(),
 		CreditCard: &CreditCard{
@@ -228,7 +240,7 @@ func TestFindTransaction(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	foundTransaction, err := testGateway.Transaction().Find(createdTransaction.Id)
+	foundTransaction, err := testGateway.Transaction().Find(ctx, createdTransaction.Id)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -241,19 +253,26 @@ func TestFindTransaction(t *testing.T) {
 func TestFindNonExistantTransaction(t *testing.T) {
 	t.Parallel()
 
-	_, err := testGateway.Transaction().Find("bad_transaction_id")
+	ctx := context.Background()
+
+	_, err := testGateway.Transaction().Find(ctx, "bad_transaction_id")
 	if err == nil {
 		t.Fatal("Did not receive error when finding an invalid tx ID")
 	}
 	if err.Error() != "Not Found (404)" {
 		t.Fatal(err)
 	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatal(err)
+	}
 }
 
 // This test will fail unless you set up your Braintree sandbox account correctly. See TESTING.md for details.
 func TestTransactionDescriptorFields(t *testing.T) {
 	t.Parallel()
 
+	ctx := context.Background()
+
 	tx := &Transaction{
 		Type:               "sale",
 		Amount:             randomAmount(),
@@ -268,7 +287,7 @@ func TestTransactionDescriptorFields(t *testing.T) {
 		},
 	}
 
-	tx2, err := testGateway.Transaction().Create(tx)
+	tx2, err := testGateway.Transaction().Create(ctx, tx)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -296,6 +315,8 @@ func TestTransactionDescriptorFields(t *testing.T) {
 func TestAllTransactionFields(t *testing.T) {
 	t.Parallel()
 
+	ctx := context.Background()
+
 	tx := &Transaction{
 		Type:    "sale",
 		Amount:  randomAmount(),
@@ -329,7 +350,7 @@ func TestAllTransactionFields(t *testing.T) {
 		},
 	}
 
-	tx2, err := testGateway.Transaction().Create(tx)
+	tx2, err := testGateway.Transaction().Create(ctx, tx)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -400,7 +421,9 @@ func TestAllTransactionFields(t *testing.T) {
 func TestTransactionDisbursementDetails(t *testing.T) {
 	t.Parallel()
 
-	txn, err := testGateway.Transaction().Find("dskdmb")
+	ctx := context.Background()
+
+	txn, err := testGateway.Transaction().Find(ctx, "dskdmb")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -428,7 +451,9 @@ func TestTransactionDisbursementDetails(t *testing.T) {
 func TestTransactionCreateFromPaymentMethodCode(t *testing.T) {
 	t.Parallel()
 
-	customer, err := testGateway.Customer().Create(&Customer{
+	ctx := context.Background()
+
+	customer, err := testGateway.Customer().Create(ctx, &Customer{
 		CreditCard: &CreditCard{
 			Number:         testCreditCards["discover"].Number,
 			ExpirationDate: "05/14",
@@ -441,7 +466,7 @@ func TestTransactionCreateFromPaymentMethodCode(t *testing.T) {
 		t.Fatal("invalid token")
 	}
 
-	tx, err := testGateway.Transaction().Create(&Transaction{
+	tx, err := testGateway.Transaction().Create(ctx, &Transaction{
 		Type:               "sale",
 		CustomerID:         customer.Id,
 		Amount:             randomAmount(),
@@ -459,9 +484,11 @@ func TestTransactionCreateFromPaymentMethodCode(t *testing.T) {
 func TestSettleTransaction(t *testing.T) {
 	t.Parallel()
 
+	ctx := context.Background()
+
 	old_environment := testGateway.Environment
 
-	txn, err := testGateway.Transaction().Create(&Transaction{
+	txn, err := testGateway.Transaction().Create(ctx, &Transaction{
 		Type:   "sale",
 		Amount: randomAmount(),
 		CreditCard: &CreditCard{
@@ -473,14 +500,14 @@ func TestSettleTransaction(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	txn, err = testGateway.Transaction().SubmitForSettlement(txn.Id, txn.Amount)
+	txn, err = testGateway.Transaction().SubmitForSettlement(ctx, txn.Id, txn.Amount)
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	testGateway.Environment = Production
 
-	_, err = testGateway.Transaction().Settle(txn.Id)
+	_, err = testGateway.Transaction().Settle(ctx, txn.Id)
 	if err.Error() != "Operation not allowed in production environment" {
 		t.Log(testGateway.Environment)
 		t.Fatal(err)
@@ -488,7 +515,7 @@ func TestSettleTransaction(t *testing.T) {
 
 	testGateway.Environment = old_environment
 
-	txn, err = testGateway.Transaction().Settle(txn.Id)
+	txn, err = testGateway.Transaction().Settle(ctx, txn.Id)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -501,7 +528,9 @@ func TestSettleTransaction(t *testing.T) {
 func TestTrxPaymentMethodNonce(t *testing.T) {
 	t.Parallel()
 
-	txn, err := testGateway.Transaction().Create(&Transaction{
+	ctx := context.Background()
+
+	txn, err := testGateway.Transaction().Create(ctx, &Transaction{
 		Type:               "sale",
 		Amount:             randomAmount(),
 		PaymentMethodNonce: "fake-apple-pay-mastercard-nonce",
@@ -510,7 +539,7 @@ func TestTrxPaymentMethodNonce(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	txn, err = testGateway.Transaction().SubmitForSettlement(txn.Id, txn.Amount)
+	txn, err = testGateway.Transaction().SubmitForSettlement(ctx, txn.Id, txn.Amount)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -519,8 +548,10 @@ func TestTrxPaymentMethodNonce(t *testing.T) {
 func TestTransactionCreateSettleAndFullRefund(t *testing.T) {
 	t.Parallel()
 
+	ctx := context.Background()
+
 	amount := NewDecimal(20000, 2)
-	txn, err := testGateway.Transaction().Create(&Transaction{
+	txn, err := testGateway.Transaction().Create(ctx, &Transaction{
 		Type:   "sale",
 		Amount: amount,
 		CreditCard: &CreditCard{
@@ -532,12 +563,12 @@ func TestTransactionCreateSettleAndFullRefund(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	txn, err = testGateway.Transaction().SubmitForSettlement(txn.Id, txn.Amount)
+	txn, err = testGateway.Transaction().SubmitForSettlement(ctx, txn.Id, txn.Amount)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	txn, err = testGateway.Transaction().Settle(txn.Id)
+	txn, err = testGateway.Transaction().Settle(ctx, txn.Id)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -547,7 +578,7 @@ func TestTransactionCreateSettleAndFullRefund(t *testing.T) {
 	}
 
 	// Refund
-	refundTxn, err := testGateway.Transaction().Refund(txn.Id)
+	refundTxn, err := testGateway.Transaction().Refund(ctx, txn.Id)
 
 	t.Log(refundTxn)
 
@@ -558,7 +589,7 @@ func TestTransactionCreateSettleAndFullRefund(t *testing.T) {
 		t.Fatal(x)
 	}
 
-	refundTxn, err = testGateway.Transaction().Settle(refundTxn.Id)
+	refundTxn, err = testGateway.Transaction().Settle(ctx, refundTxn.Id)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -572,7 +603,7 @@ func TestTransactionCreateSettleAndFullRefund(t *testing.T) {
 	}
 
 	// Check that the refund shows up in the original transaction
-	txn, err = testGateway.Transaction().Find(txn.Id)
+	txn, err = testGateway.Transaction().Find(ctx, txn.Id)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -582,7 +613,7 @@ func TestTransactionCreateSettleAndFullRefund(t *testing.T) {
 	}
 
 	// Second refund should fail
-	refundTxn, err = testGateway.Transaction().Refund(txn.Id)
+	refundTxn, err = testGateway.Transaction().Refund(ctx, txn.Id)
 	t.Log(refundTxn)
 
 	if err.Error() != "Transaction has already been completely refunded." {
@@ -593,10 +624,12 @@ func TestTransactionCreateSettleAndFullRefund(t *testing.T) {
 func TestTransactionCreateSettleAndPartialRefund(t *testing.T) {
 	t.Parallel()
 
+	ctx := context.Background()
+
 	amount := NewDecimal(10000, 2)
 	refundAmt1 := NewDecimal(5000, 2)
 	refundAmt2 := NewDecimal(5001, 2)
-	txn, err := testGateway.Transaction().Create(&Transaction{
+	txn, err := testGateway.Transaction().Create(ctx, &Transaction{
 		Type:   "sale",
 		Amount: amount,
 		CreditCard: &CreditCard{
@@ -608,12 +641,12 @@ func TestTransactionCreateSettleAndPartialRefund(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	txn, err = testGateway.Transaction().SubmitForSettlement(txn.Id, txn.Amount)
+	txn, err = testGateway.Transaction().SubmitForSettlement(ctx, txn.Id, txn.Amount)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	txn, err = testGateway.Transaction().Settle(txn.Id)
+	txn, err = testGateway.Transaction().Settle(ctx, txn.Id)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -623,7 +656,7 @@ func TestTransactionCreateSettleAndPartialRefund(t *testing.T) {
 	}
 
 	// Refund
-	refundTxn, err := testGateway.Transaction().Refund(txn.Id, refundAmt1)
+	refundTxn, err := testGateway.Transaction().Refund(ctx, txn.Id, refundAmt1)
 
 	t.Log(refundTxn)
 
@@ -634,7 +667,7 @@ func TestTransactionCreateSettleAndPartialRefund(t *testing.T) {
 		t.Fatal(x)
 	}
 
-	refundTxn, err = testGateway.Transaction().Settle(refundTxn.Id)
+	refundTxn, err = testGateway.Transaction().Settle(ctx, refundTxn.Id)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -644,7 +677,7 @@ func TestTransactionCreateSettleAndPartialRefund(t *testing.T) {
 	}
 
 	// Refund amount too large
-	refundTxn, err = testGateway.Transaction().Refund(txn.Id, refundAmt2)
+	refundTxn, err = testGateway.Transaction().Refund(ctx, txn.Id, refundAmt2)
 
 	t.Log(refundTxn)
 
@@ -653,11 +686,60 @@ func TestTransactionCreateSettleAndPartialRefund(t *testing.T) {
 	}
 }
 
+func TestTransactionCreateSettleAndRefundWithRequest(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	txn, err := testGateway.Transaction().Create(ctx, &Transaction{
+		Type:   "sale",
+		Amount: NewDecimal(10000, 2),
+		CreditCard: &CreditCard{
+			Number:         testCreditCards["visa"].Number,
+			ExpirationDate: "05/14",
+		},
+		Options: &TransactionOptions{SubmitForSettlement: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	txn, err = testGateway.Transaction().Settle(ctx, txn.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	orderId := testhelpers.RandomString()
+	refund, err := testGateway.Transaction().RefundWithRequest(ctx, txn.Id, &RefundRequest{
+		Amount:  NewDecimal(3000, 2),
+		OrderId: orderId,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if refund.OrderId != orderId || refund.Amount.Cmp(NewDecimal(3000, 2)) != 0 {
+		t.Fatalf("got order id %q, amount %s", refund.OrderId, refund.Amount)
+	}
+
+	txn, err = testGateway.Transaction().Find(ctx, txn.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	remaining, err := testGateway.Transaction().RefundableAmount(ctx, txn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if remaining.Cmp(NewDecimal(7000, 2)) != 0 {
+		t.Fatalf("got refundable amount %s, want 70.00", remaining)
+	}
+}
+
 func TestTransactionCreateSettleCheckCreditCardDetails(t *testing.T) {
 	t.Parallel()
 
+	ctx := context.Background()
+
 	amount := NewDecimal(10000, 2)
-	txn, err := testGateway.Transaction().Create(&Transaction{
+	txn, err := testGateway.Transaction().Create(ctx, &Transaction{
 		Type:   "sale",
 		Amount: amount,
 		CreditCard: &CreditCard{
@@ -678,12 +760,12 @@ func TestTransactionCreateSettleCheckCreditCardDetails(t *testing.T) {
 			"Visa", txn.CreditCard.CardType)
 	}
 
-	txn, err = testGateway.Transaction().SubmitForSettlement(txn.Id, txn.Amount)
+	txn, err = testGateway.Transaction().SubmitForSettlement(ctx, txn.Id, txn.Amount)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	txn, err = testGateway.Transaction().Settle(txn.Id)
+	txn, err = testGateway.Transaction().Settle(ctx, txn.Id)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -692,3 +774,134 @@ func TestTransactionCreateSettleCheckCreditCardDetails(t *testing.T) {
 		t.Fatal(txn.Status)
 	}
 }
+
+func TestTransactionLineItems(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	tx, err := testGateway.Transaction().Create(ctx, &Transaction{
+		Type:                "sale",
+		Amount:              NewDecimal(1100, 2),
+		PurchaseOrderNumber: "PO-12345",
+		TaxAmount:           NewDecimal(100, 2),
+		ShippingAmount:      NewDecimal(0, 2),
+		ShipsFromPostalCode: "60654",
+		CreditCard: &CreditCard{
+			Number:         testCreditCards["visa"].Number,
+			ExpirationDate: "05/14",
+		},
+		LineItems: &TransactionLineItems{LineItems: []*TransactionLineItem{{
+			Quantity:    NewDecimal(2, 0),
+			Name:        "Widget",
+			Kind:        TransactionLineItemKindDebit,
+			UnitAmount:  NewDecimal(500, 2),
+			TotalAmount: NewDecimal(1000, 2),
+			ProductCode: "W-1",
+		}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.PurchaseOrderNumber != "PO-12345" || tx.TaxAmount.Cmp(NewDecimal(100, 2)) != 0 {
+		t.Fatalf("got purchase order %q, tax %s", tx.PurchaseOrderNumber, tx.TaxAmount)
+	}
+
+	items, err := testGateway.Transaction().LineItems(ctx, tx.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("got %d line items, want 1", len(items))
+	}
+	if item := items[0]; item.Name != "Widget" || item.ProductCode != "W-1" || item.TotalAmount.Cmp(NewDecimal(1000, 2)) != 0 {
+		t.Fatalf("got %+v", item)
+	}
+}
+
+func TestTransactionPartialSettlement(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	auth, err := testGateway.Transaction().Create(ctx, &Transaction{
+		Type:   "sale",
+		Amount: NewDecimal(1000, 2),
+		CreditCard: &CreditCard{
+			Number:         testCreditCards["visa"].Number,
+			ExpirationDate: "05/14",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var children []*Transaction
+	for _, amount := range []*Decimal{NewDecimal(400, 2), NewDecimal(250, 2)} {
+		child, err := testGateway.Transaction().SubmitForPartialSettlement(ctx, auth.Id, amount)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if child.AuthorizedTransactionId != auth.Id || child.Status != TransactionStatusSubmittedForSettlement {
+			t.Fatalf("got authorized transaction %q, status %q", child.AuthorizedTransactionId, child.Status)
+		}
+		children = append(children, child)
+	}
+
+	auth, err = testGateway.Transaction().Find(ctx, auth.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if auth.PartialSettlementTransactionIds == nil || len(*auth.PartialSettlementTransactionIds) != 2 {
+		t.Fatalf("got partial settlement ids %v", auth.PartialSettlementTransactionIds)
+	}
+	if got := auth.CapturableAmount(children); got.Cmp(NewDecimal(350, 2)) != 0 {
+		t.Fatalf("got capturable amount %s, want 3.50", got)
+	}
+}
+
+func TestTransactionCloneAndUpdateDetails(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	tx, err := testGateway.Transaction().Create(ctx, &Transaction{
+		Type:   "sale",
+		Amount: NewDecimal(1000, 2),
+		CreditCard: &CreditCard{
+			Number:         testCreditCards["visa"].Number,
+			ExpirationDate: "05/14",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clone, err := testGateway.Transaction().Clone(ctx, tx.Id, &TransactionCloneRequest{
+		Amount:  NewDecimal(1200, 2),
+		Channel: "repeat",
+		Options: &TransactionCloneOptions{SubmitForSettlement: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if clone.Id == tx.Id || clone.Amount.Cmp(NewDecimal(1200, 2)) != 0 || clone.Status != TransactionStatusSubmittedForSettlement {
+		t.Fatalf("got clone %s of %s for %s, status %q", clone.Id, tx.Id, clone.Amount, clone.Status)
+	}
+
+	updated, err := testGateway.Transaction().UpdateDetails(ctx, clone.Id, &TransactionUpdateDetailsRequest{
+		Amount:  NewDecimal(1100, 2),
+		OrderId: testhelpers.RandomString(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Amount.Cmp(NewDecimal(1100, 2)) != 0 {
+		t.Fatalf("got amount %s, want 11.00", updated.Amount)
+	}
+
+	_, err = testGateway.Transaction().UpdateDetails(ctx, clone.Id, &TransactionUpdateDetailsRequest{Amount: NewDecimal(5000, 2)})
+	if _, ok := err.(*BraintreeError); !ok {
+		t.Fatalf("got %v, want a validation error for an amount above the authorization", err)
+	}
+}
@@ -0,0 +1,40 @@
+package braintree
+
+import "encoding/xml"
+
+const (
+	TransactionLineItemKindDebit  = "debit"
+	TransactionLineItemKindCredit = "credit"
+)
+
+// TransactionLineItem is a Level 3 line item of a purchasing card transaction. Kind
+// is whether the item is charged or credited, see the TransactionLineItemKind
+// constants.
+type TransactionLineItem struct {
+	Quantity       *Decimal `xml:"quantity,omitempty"`
+	Name           string   `xml:"name,omitempty"`
+	Description    string   `xml:"description,omitempty"`
+	Kind           string   `xml:"kind,omitempty"`
+	UnitAmount     *Decimal `xml:"unit-amount,omitempty"`
+	UnitTaxAmount  *Decimal `xml:"unit-tax-amount,omitempty"`
+	TotalAmount    *Decimal `xml:"total-amount,omitempty"`
+	DiscountAmount *Decimal `xml:"discount-amount,omitempty"`
+	TaxAmount      *Decimal `xml:"tax-amount,omitempty"`
+	UnitOfMeasure  string   `xml:"unit-of-measure,omitempty"`
+	ProductCode    string   `xml:"product-code,omitempty"`
+	CommodityCode  string   `xml:"commodity-code,omitempty"`
+	URL            string   `xml:"url,omitempty"`
+}
+
+type TransactionLineItems struct {
+	XMLName   string                 `xml:"line-items"`
+	LineItems []*TransactionLineItem `xml:"line-item"`
+}
+
+func (l TransactionLineItems) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	type lineItems struct {
+		Type      string                 `xml:"type,attr"`
+		LineItems []*TransactionLineItem `xml:"line-item"`
+	}
+	return e.EncodeElement(lineItems{"array", l.LineItems}, start)
+}
@@ -0,0 +1,171 @@
+package braintree
+
+const (
+	CardTypeAmericanExpress = "American Express"
+	CardTypeDinersClub      = "Diners Club"
+	CardTypeDiscover        = "Discover"
+	CardTypeJCB             = "JCB"
+	CardTypeMaestro         = "Maestro"
+	CardTypeMasterCard      = "MasterCard"
+	CardTypeUnionPay        = "UnionPay"
+	CardTypeVisa            = "Visa"
+)
+
+// TransactionSearch builds a transaction search query from typed criteria, e.g.
+//
+//	s := new(TransactionSearch)
+//	s.Status().In(TransactionStatusSettled, TransactionStatusSettling)
+//	s.Amount().AtLeast(NewDecimal(0, 2))
+//	s.CreatedAt().Between(start, end)
+//	it, err := bt.Transaction().SearchAll(ctx, s.Query())
+//
+// Criteria without a value are left out of the query.
+type TransactionSearch struct {
+	searchCriteria
+}
+
+func (s *TransactionSearch) Id() *TextCriterion {
+	return s.text("id")
+}
+
+// Ids matches any of the given transaction ids.
+func (s *TransactionSearch) Ids() *MultipleValueCriterion {
+	return s.multipleValue("ids")
+}
+
+// Status matches the transaction status, see the TransactionStatus constants.
+func (s *TransactionSearch) Status() *MultipleValueCriterion {
+	return s.multipleValue("status")
+}
+
+// Type matches the transaction type, see the TransactionType constants.
+func (s *TransactionSearch) Type() *MultipleValueCriterion {
+	return s.multipleValue("type")
+}
+
+// Source matches how the transaction was created, see the TransactionSource constants.
+func (s *TransactionSearch) Source() *MultipleValueCriterion {
+	return s.multipleValue("source")
+}
+
+// Refund matches transactions that were, or were not, refunded.
+func (s *TransactionSearch) Refund() *KeyValueCriterion {
+	return s.keyValue("refund")
+}
+
+func (s *TransactionSearch) Amount() *DecimalRangeCriterion {
+	return s.decimalRange("amount")
+}
+
+func (s *TransactionSearch) OrderId() *TextCriterion {
+	return s.text("order-id")
+}
+
+func (s *TransactionSearch) PaymentMethodToken() *TextCriterion {
+	return s.text("payment-method-token")
+}
+
+func (s *TransactionSearch) MerchantAccountId() *MultipleValueCriterion {
+	return s.multipleValue("merchant-account-id")
+}
+
+func (s *TransactionSearch) SettlementBatchId() *TextCriterion {
+	return s.text("settlement-batch-id")
+}
+
+func (s *TransactionSearch) ProcessorAuthorizationCode() *TextCriterion {
+	return s.text("processor-authorization-code")
+}
+
+func (s *TransactionSearch) CustomerId() *TextCriterion {
+	return s.text("customer-id")
+}
+
+func (s *TransactionSearch) CustomerEmail() *TextCriterion {
+	return s.text("customer-email")
+}
+
+func (s *TransactionSearch) CustomerFirstName() *TextCriterion {
+	return s.text("customer-first-name")
+}
+
+func (s *TransactionSearch) CustomerLastName() *TextCriterion {
+	return s.text("customer-last-name")
+}
+
+func (s *TransactionSearch) CustomerCompany() *TextCriterion {
+	return s.text("customer-company")
+}
+
+// CreditCardCardType matches the card type, see the CardType constants.
+func (s *TransactionSearch) CreditCardCardType() *MultipleValueCriterion {
+	return s.multipleValue("credit-card-card-type")
+}
+
+func (s *TransactionSearch) CreditCardNumber() *PartialMatchCriterion {
+	return s.partialMatch("credit-card-number")
+}
+
+// CreditCardExpirationDate matches expiration dates formatted as MM/YYYY.
+func (s *TransactionSearch) CreditCardExpirationDate() *EqualityCriterion {
+	return s.equality("credit-card-expiration-date")
+}
+
+func (s *TransactionSearch) CreditCardCardholderName() *TextCriterion {
+	return s.text("credit-card-cardholder-name")
+}
+
+func (s *TransactionSearch) PayPalPayerEmail() *TextCriterion {
+	return s.text("paypal-payer-email")
+}
+
+func (s *TransactionSearch) CreatedAt() *TimeRangeCriterion {
+	return s.timeRange("created-at")
+}
+
+func (s *TransactionSearch) AuthorizedAt() *TimeRangeCriterion {
+	return s.timeRange("authorized-at")
+}
+
+func (s *TransactionSearch) AuthorizationExpiredAt() *TimeRangeCriterion {
+	return s.timeRange("authorization-expired-at")
+}
+
+func (s *TransactionSearch) SubmittedForSettlementAt() *TimeRangeCriterion {
+	return s.timeRange("submitted-for-settlement-at")
+}
+
+func (s *TransactionSearch) SettledAt() *TimeRangeCriterion {
+	return s.timeRange("settled-at")
+}
+
+func (s *TransactionSearch) VoidedAt() *TimeRangeCriterion {
+	return s.timeRange("voided-at")
+}
+
+func (s *TransactionSearch) FailedAt() *TimeRangeCriterion {
+	return s.timeRange("failed-at")
+}
+
+func (s *TransactionSearch) GatewayRejectedAt() *TimeRangeCriterion {
+	return s.timeRange("gateway-rejected-at")
+}
+
+func (s *TransactionSearch) ProcessorDeclinedAt() *TimeRangeCriterion {
+	return s.timeRange("processor-declined-at")
+}
+
+func (s *TransactionSearch) DisbursementDate() *TimeRangeCriterion {
+	return s.timeRange("disbursement-date")
+}
+
+func (s *TransactionSearch) DisputeDate() *TimeRangeCriterion {
+	return s.timeRange("dispute-date")
+}
+
+// CustomField matches the value of the custom field with the given API name. Only
+// fields marked as searchable in the control panel can be searched. Searching fails
+// if name is not a valid XML element name.
+func (s *TransactionSearch) CustomField(name string) *TextCriterion {
+	return s.customField(name)
+}
@@ -0,0 +1,520 @@
+package braintree
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const testTransactionDetailsXML = `<transaction>
+  <id>dskdmb</id>
+  <status>settled</status>
+  <type>sale</type>
+  <currency-iso-code>USD</currency-iso-code>
+  <amount>7.00</amount>
+  <avs-error-response-code nil="true"></avs-error-response-code>
+  <avs-postal-code-response-code>M</avs-postal-code-response-code>
+  <avs-street-address-response-code>I</avs-street-address-response-code>
+  <cvv-response-code>N</cvv-response-code>
+  <gateway-rejection-reason nil="true"></gateway-rejection-reason>
+  <voice-referral-number nil="true"></voice-referral-number>
+  <purchase-order-number>PO-1234</purchase-order-number>
+  <tax-amount>0.50</tax-amount>
+  <tax-exempt type="boolean">false</tax-exempt>
+  <status-history type="array">
+    <status-event>
+      <timestamp type="datetime">2013-10-07T17:26:14Z</timestamp>
+      <status>authorized</status>
+      <amount>7.00</amount>
+      <user>eaigner</user>
+      <transaction-source>recurring</transaction-source>
+    </status-event>
+    <status-event>
+      <timestamp type="datetime">2013-10-08T07:06:38Z</timestamp>
+      <status>settled</status>
+      <amount>7.00</amount>
+      <user nil="true"></user>
+      <transaction-source></transaction-source>
+    </status-event>
+  </status-history>
+  <plan-id>bronze</plan-id>
+  <subscription-id>jqsydb</subscription-id>
+  <subscription>
+    <billing-period-end-date type="date">2013-11-06</billing-period-end-date>
+    <billing-period-start-date type="date">2013-10-07</billing-period-start-date>
+  </subscription>
+  <add-ons type="array">
+    <add-on>
+      <id>seat</id>
+      <amount>1.00</amount>
+      <quantity type="integer">2</quantity>
+    </add-on>
+  </add-ons>
+  <discounts type="array"/>
+  <recurring type="boolean">true</recurring>
+  <channel>partner</channel>
+  <escrow-status nil="true"></escrow-status>
+</transaction>`
+
+func TestTransactionUnmarshalDetails(t *testing.T) {
+	t.Parallel()
+
+	var tx Transaction
+	if err := xml.Unmarshal([]byte(testTransactionDetailsXML), &tx); err != nil {
+		t.Fatal(err)
+	}
+
+	if tx.CurrencyISOCode != "USD" || tx.AVSErrorResponseCode != "" ||
+		tx.AVSPostalCodeResponseCode != AVSResponseCodeMatches ||
+		tx.AVSStreetAddressResponseCode != AVSResponseCodeNotProvided ||
+		tx.CVVResponseCode != AVSResponseCodeDoesNotMatch {
+		t.Fatalf("got currency %q, AVS %q/%q/%q, CVV %q", tx.CurrencyISOCode, tx.AVSErrorResponseCode,
+			tx.AVSPostalCodeResponseCode, tx.AVSStreetAddressResponseCode, tx.CVVResponseCode)
+	}
+
+	var failed Transaction
+	if err := xml.Unmarshal([]byte(`<transaction><avs-error-response-code>E</avs-error-response-code><avs-postal-code-response-code>U</avs-postal-code-response-code><cvv-response-code>S</cvv-response-code></transaction>`), &failed); err != nil {
+		t.Fatal(err)
+	}
+	if failed.AVSErrorResponseCode != AVSErrorResponseCodeSystemError ||
+		failed.AVSPostalCodeResponseCode != AVSResponseCodeNotVerified ||
+		failed.CVVResponseCode != CVVResponseCodeIssuerDoesNotParticipate {
+		t.Fatalf("got AVS %q/%q, CVV %q", failed.AVSErrorResponseCode, failed.AVSPostalCodeResponseCode, failed.CVVResponseCode)
+	}
+	if tx.PurchaseOrderNumber != "PO-1234" || tx.TaxAmount.Cmp(NewDecimal(50, 2)) != 0 || tx.TaxExempt {
+		t.Fatalf("got purchase order %q, tax %v, exempt %v", tx.PurchaseOrderNumber, tx.TaxAmount, tx.TaxExempt)
+	}
+	if tx.SubscriptionId != "jqsydb" || tx.SubscriptionDetails == nil ||
+		tx.SubscriptionDetails.BillingPeriodStartDate.Format("2006-01-02") != "2013-10-07" ||
+		tx.SubscriptionDetails.BillingPeriodEndDate.Format("2006-01-02") != "2013-11-06" {
+		t.Fatalf("got subscription %q, %+v", tx.SubscriptionId, tx.SubscriptionDetails)
+	}
+	if tx.AddOns == nil || len(tx.AddOns.AddOns) != 1 || tx.AddOns.AddOns[0].Quantity != 2 {
+		t.Fatalf("got add-ons %+v", tx.AddOns)
+	}
+	if tx.Discounts == nil || len(tx.Discounts.Discounts) != 0 {
+		t.Fatalf("got discounts %+v", tx.Discounts)
+	}
+	if !tx.Recurring || tx.Channel != "partner" || tx.EscrowStatus != "" {
+		t.Fatalf("got recurring %v, channel %q, escrow status %q", tx.Recurring, tx.Channel, tx.EscrowStatus)
+	}
+
+	if tx.StatusHistory == nil || len(tx.StatusHistory.Events) != 2 {
+		t.Fatalf("got status history %+v", tx.StatusHistory)
+	}
+	event := tx.StatusHistory.Events[0]
+	if event.Status != TransactionStatusAuthorized || event.Amount.Cmp(NewDecimal(700, 2)) != 0 || event.User != "eaigner" ||
+		event.TransactionSource != TransactionSourceRecurring ||
+		!event.Timestamp.Equal(time.Date(2013, time.October, 7, 17, 26, 14, 0, time.UTC)) {
+		t.Fatalf("got %+v", event)
+	}
+	if event := tx.StatusHistory.Events[1]; event.Status != TransactionStatusSettled || event.User != "" {
+		t.Fatalf("got %+v", event)
+	}
+}
+
+func TestTransactionResponseCategoryGatewayRejected(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		reason string
+		want   ResponseCategory
+	}{
+		{GatewayRejectionReasonCVV, ResponseCategoryFraud},
+		{GatewayRejectionReasonAVSAndCVV, ResponseCategoryFraud},
+		{GatewayRejectionReasonRiskThreshold, ResponseCategoryFraud},
+		{GatewayRejectionReasonDuplicate, ResponseCategoryValidation},
+		{GatewayRejectionReasonApplicationIncomplete, ResponseCategoryValidation},
+		{"", ResponseCategoryUnknown},
+	}
+	for _, tt := range tests {
+		tx := &Transaction{Status: TransactionStatusGatewayRejected, GatewayRejectionReason: tt.reason}
+		if got := tx.ResponseCategory(); got != tt.want {
+			t.Errorf("%q: got %q, want %q", tt.reason, got, tt.want)
+		}
+	}
+}
+
+func TestTransactionLevel3MarshalXML(t *testing.T) {
+	t.Parallel()
+
+	tx := &Transaction{
+		Type:                TransactionTypeSale,
+		Amount:              NewDecimal(1100, 2),
+		PurchaseOrderNumber: "PO-1",
+		TaxAmount:           NewDecimal(100, 2),
+		ShippingAmount:      NewDecimal(0, 2),
+		ShipsFromPostalCode: "60654",
+		LineItems: &TransactionLineItems{LineItems: []*TransactionLineItem{{
+			Quantity:    NewDecimal(2, 0),
+			Name:        "Widget",
+			Kind:        TransactionLineItemKindDebit,
+			UnitAmount:  NewDecimal(500, 2),
+			TotalAmount: NewDecimal(1000, 2),
+			ProductCode: "W-1",
+		}}},
+	}
+	b, err := xml.Marshal(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := `<transaction><type>sale</type><amount>11.00</amount><purchase-order-number>PO-1</purchase-order-number>` +
+		`<tax-amount>1.00</tax-amount><shipping-amount>0.00</shipping-amount><ships-from-postal-code>60654</ships-from-postal-code>` +
+		`<line-items type="array"><line-item><quantity>2</quantity><name>Widget</name><kind>debit</kind>` +
+		`<unit-amount>5.00</unit-amount><total-amount>10.00</total-amount><product-code>W-1</product-code></line-item></line-items></transaction>`
+	if string(b) != expect {
+		t.Fatalf("got %s, want %s", b, expect)
+	}
+}
+
+func TestTransactionGatewayLineItems(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/merchants/merchant-id/transactions/abc123/line_items" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writeXML(w, http.StatusOK, `<line-items type="array">
+  <line-item>
+    <quantity>2.0000</quantity>
+    <name>Widget</name>
+    <kind>debit</kind>
+    <unit-amount>5.00</unit-amount>
+    <unit-tax-amount>0.50</unit-tax-amount>
+    <total-amount>10.00</total-amount>
+    <unit-of-measure>each</unit-of-measure>
+    <commodity-code>44121700</commodity-code>
+  </line-item>
+</line-items>`)
+	}))
+	defer srv.Close()
+
+	items, err := testServerGateway(srv).Transaction().LineItems(context.Background(), "abc123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("got %d line items, want 1", len(items))
+	}
+	item := items[0]
+	if item.Name != "Widget" || item.Kind != TransactionLineItemKindDebit || item.Quantity.Cmp(NewDecimal(2, 0)) != 0 ||
+		item.UnitTaxAmount.Cmp(NewDecimal(50, 2)) != 0 ||
+		item.UnitOfMeasure != "each" || item.CommodityCode != "44121700" {
+		t.Fatalf("got %+v", item)
+	}
+
+	if _, err := testServerGateway(srv).Transaction().LineItems(context.Background(), "missing"); err == nil {
+		t.Fatal("expected an error for a missing transaction")
+	}
+}
+
+func TestTransactionEscrow(t *testing.T) {
+	t.Parallel()
+
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		paths = append(paths, r.URL.Path)
+		status := map[string]EscrowStatus{
+			"/merchants/merchant-id/transactions/abc123/hold_in_escrow":      EscrowStatusHoldPending,
+			"/merchants/merchant-id/transactions/abc123/release_from_escrow": EscrowStatusReleasePending,
+			"/merchants/merchant-id/transactions/abc123/cancel_release":      EscrowStatusHeld,
+		}[r.URL.Path]
+		writeXML(w, http.StatusOK, `<transaction><id>abc123</id><escrow-status>`+string(status)+`</escrow-status></transaction>`)
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	g := testServerGateway(srv).Transaction()
+
+	tx, err := g.HoldInEscrow(ctx, "abc123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.EscrowStatus != EscrowStatusHoldPending {
+		t.Fatalf("got escrow status %q after hold", tx.EscrowStatus)
+	}
+	if tx, err = g.ReleaseFromEscrow(ctx, "abc123"); err != nil {
+		t.Fatal(err)
+	}
+	if tx.EscrowStatus != EscrowStatusReleasePending {
+		t.Fatalf("got escrow status %q after release", tx.EscrowStatus)
+	}
+	if tx, err = g.CancelRelease(ctx, "abc123"); err != nil {
+		t.Fatal(err)
+	}
+	if tx.EscrowStatus != EscrowStatusHeld {
+		t.Fatalf("got escrow status %q after cancelling the release", tx.EscrowStatus)
+	}
+	if len(paths) != 3 {
+		t.Fatalf("got requests %v", paths)
+	}
+}
+
+func TestTransactionHoldInEscrowNotSubMerchant(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeXML(w, http.StatusUnprocessableEntity, `<api-error-response><errors><errors type="array"/><transaction><errors type="array"><error><code>91560</code><attribute type="symbol">base</attribute><message>Transaction could not be held in escrow.</message></error></errors></transaction></errors><message>Transaction could not be held in escrow.</message></api-error-response>`)
+	}))
+	defer srv.Close()
+
+	_, err := testServerGateway(srv).Transaction().HoldInEscrow(context.Background(), "abc123")
+	escrowErr, ok := err.(*EscrowError)
+	if !ok {
+		t.Fatalf("got %T %v, want an *EscrowError", err, err)
+	}
+	if escrowErr.TransactionId != "abc123" || escrowErr.Code != ErrorCodeTransactionCannotHoldInEscrow || escrowErr.Err.StatusCode() != 422 {
+		t.Fatalf("got %+v", escrowErr)
+	}
+	if want := "braintree: transaction abc123: " + ErrorCodeTransactionCannotHoldInEscrow.Message(); err.Error() != want {
+		t.Fatalf("got %q, want %q", err.Error(), want)
+	}
+
+	// Other validation errors are returned as is.
+	_, err = testServerGateway(srv).Transaction().ReleaseFromEscrow(context.Background(), "abc123")
+	if _, ok := err.(*BraintreeError); !ok {
+		t.Fatalf("got %T %v, want a *BraintreeError", err, err)
+	}
+}
+
+func TestTransactionCreateHoldInEscrowWithoutMerchantAccount(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected request")
+	}))
+	defer srv.Close()
+
+	_, err := testServerGateway(srv).Transaction().Create(context.Background(), &Transaction{
+		Type:    TransactionTypeSale,
+		Amount:  NewDecimal(1000, 2),
+		Options: &TransactionOptions{HoldInEscrow: true},
+	})
+	if err == nil {
+		t.Fatal("expected an error without a sub-merchant account")
+	}
+}
+
+func TestTransactionSubmitForPartialSettlement(t *testing.T) {
+	t.Parallel()
+
+	srv := testRequestServer(t, "POST", "transactions/auth1/submit_for_partial_settlement",
+		`<transaction><amount>4.00</amount></transaction>`,
+		http.StatusCreated, `<transaction><id>child1</id><status>submitted_for_settlement</status><amount>4.00</amount><authorized-transaction-id>auth1</authorized-transaction-id></transaction>`)
+	defer srv.Close()
+
+	tx, err := testServerGateway(srv).Transaction().SubmitForPartialSettlement(context.Background(), "auth1", NewDecimal(400, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Id != "child1" || tx.AuthorizedTransactionId != "auth1" {
+		t.Fatalf("got %+v", tx)
+	}
+}
+
+func TestTransactionCapturableAmount(t *testing.T) {
+	t.Parallel()
+
+	var auth Transaction
+	err := xml.Unmarshal([]byte(`<transaction>
+  <id>auth1</id>
+  <status>settlement_pending</status>
+  <amount>10.00</amount>
+  <partial-settlement-transaction-ids type="array">
+    <item>child1</item>
+    <item>child2</item>
+    <item>child3</item>
+  </partial-settlement-transaction-ids>
+</transaction>`), &auth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if auth.PartialSettlementTransactionIds == nil || len(*auth.PartialSettlementTransactionIds) != 3 {
+		t.Fatalf("got partial settlement ids %v", auth.PartialSettlementTransactionIds)
+	}
+
+	children := []*Transaction{
+		{Id: "child1", AuthorizedTransactionId: "auth1", Status: TransactionStatusSettled, Amount: NewDecimal(400, 2)},
+		{Id: "child2", AuthorizedTransactionId: "auth1", Status: TransactionStatusSubmittedForSettlement, Amount: NewDecimal(25, 1)},
+		{Id: "child3", AuthorizedTransactionId: "auth1", Status: TransactionStatusVoided, Amount: NewDecimal(300, 2)},
+		{Id: "other", AuthorizedTransactionId: "auth2", Status: TransactionStatusSettled, Amount: NewDecimal(100, 2)},
+	}
+	if got := auth.CapturableAmount(children); got.Cmp(NewDecimal(350, 2)) != 0 || got.Scale != 2 {
+		t.Fatalf("got %v, want 3.50", got)
+	}
+	if got := auth.CapturableAmount(nil); got.Cmp(auth.Amount) != 0 {
+		t.Fatalf("got %v, want the full amount", got)
+	}
+
+	children = append(children, &Transaction{AuthorizedTransactionId: "auth1", Status: TransactionStatusSettling, Amount: NewDecimal(1000, 2)})
+	if got := auth.CapturableAmount(children); got.Cmp(NewDecimal(0, 2)) != 0 {
+		t.Fatalf("got %v, want 0", got)
+	}
+
+	auth.Status = TransactionStatusVoided
+	if got := auth.CapturableAmount(nil); got.Cmp(NewDecimal(0, 2)) != 0 {
+		t.Fatalf("got %v for a voided authorization, want 0", got)
+	}
+}
+
+func TestTransactionClone(t *testing.T) {
+	t.Parallel()
+
+	srv := testRequestServer(t, "POST", "transactions/abc123/clone",
+		`<transaction-clone><amount>12.00</amount><channel>repeat</channel><options><submit-for-settlement>true</submit-for-settlement></options></transaction-clone>`,
+		http.StatusCreated, `<transaction><id>def456</id><status>submitted_for_settlement</status><amount>12.00</amount><channel>repeat</channel></transaction>`)
+	defer srv.Close()
+
+	tx, err := testServerGateway(srv).Transaction().Clone(context.Background(), "abc123", &TransactionCloneRequest{
+		Amount:  NewDecimal(1200, 2),
+		Channel: "repeat",
+		Options: &TransactionCloneOptions{SubmitForSettlement: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Id != "def456" || tx.Channel != "repeat" || tx.Status != TransactionStatusSubmittedForSettlement {
+		t.Fatalf("got %+v", tx)
+	}
+}
+
+func TestTransactionUpdateDetails(t *testing.T) {
+	t.Parallel()
+
+	srv := testRequestServer(t, "PUT", "transactions/abc123/update_details",
+		`<transaction><order-id>order-2</order-id><descriptor><name>company*product</name><phone>5555555555</phone></descriptor></transaction>`,
+		http.StatusOK, `<transaction><id>abc123</id><order-id>order-2</order-id><descriptor><name>company*product</name><phone>5555555555</phone></descriptor></transaction>`)
+	defer srv.Close()
+
+	tx, err := testServerGateway(srv).Transaction().UpdateDetails(context.Background(), "abc123", &TransactionUpdateDetailsRequest{
+		OrderId:    "order-2",
+		Descriptor: &Descriptor{Name: "company*product", Phone: "5555555555"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.OrderId != "order-2" || tx.Descriptor == nil || tx.Descriptor.Name != "company*product" {
+		t.Fatalf("got %+v", tx)
+	}
+
+	srv = testRequestServer(t, "PUT", "transactions/abc123/update_details",
+		`<transaction><amount>100.00</amount></transaction>`,
+		http.StatusUnprocessableEntity, `<api-error-response><errors><errors type="array"/><transaction><errors type="array"><error><code>91522</code><attribute type="symbol">amount</attribute><message>Settlement amount cannot be more than the authorized amount.</message></error></errors></transaction></errors><message>Settlement amount cannot be more than the authorized amount.</message></api-error-response>`)
+	defer srv.Close()
+
+	_, err = testServerGateway(srv).Transaction().UpdateDetails(context.Background(), "abc123", &TransactionUpdateDetailsRequest{Amount: NewDecimal(10000, 2)})
+	bte, ok := err.(*BraintreeError)
+	if !ok {
+		t.Fatalf("got %T %v, want a *BraintreeError", err, err)
+	}
+	if errs := bte.For("Transaction").On("Amount"); len(errs) != 1 || errs[0].Code != ErrorCodeTransactionSettlementAmountIsTooLarge {
+		t.Fatalf("got %+v", bte.All())
+	}
+}
+
+func TestTransactionRefundWithRequest(t *testing.T) {
+	t.Parallel()
+
+	srv := testRequestServer(t, "POST", "transactions/abc123/refund",
+		`<transaction><amount>2.50</amount><order-id>refund-1</order-id></transaction>`,
+		http.StatusCreated, `<transaction><id>ref1</id><type>credit</type><amount>2.50</amount><order-id>refund-1</order-id><refunded-transaction-id>abc123</refunded-transaction-id></transaction>`)
+	defer srv.Close()
+
+	refund, err := testServerGateway(srv).Transaction().RefundWithRequest(context.Background(), "abc123", &RefundRequest{
+		Amount:  NewDecimal(250, 2),
+		OrderId: "refund-1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if refund.Id != "ref1" || refund.OrderId != "refund-1" || refund.RefundedTransactionId == nil || *refund.RefundedTransactionId != "abc123" {
+		t.Fatalf("got %+v", refund)
+	}
+}
+
+func TestTransactionRefundableAmount(t *testing.T) {
+	t.Parallel()
+
+	refunds := map[string]string{
+		"ref1": `<transaction><id>ref1</id><type>credit</type><status>settled</status><amount>2.50</amount><refunded-transaction-id>abc123</refunded-transaction-id></transaction>`,
+		"ref2": `<transaction><id>ref2</id><type>credit</type><status>submitted_for_settlement</status><amount>1.00</amount><refunded-transaction-id>abc123</refunded-transaction-id></transaction>`,
+		"ref3": `<transaction><id>ref3</id><type>credit</type><status>voided</status><amount>5.00</amount><refunded-transaction-id>abc123</refunded-transaction-id></transaction>`,
+	}
+	// One page holds two refunds, so the three refunds span two pages.
+	var pages [][]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var query struct {
+			Ids []string `xml:"ids>item"`
+		}
+		if err := xml.NewDecoder(r.Body).Decode(&query); err != nil {
+			t.Error(err)
+		}
+		switch r.URL.Path {
+		case "/merchants/merchant-id/transactions/advanced_search_ids":
+			body := `<search-results><page-size>2</page-size><ids type="array">`
+			for _, id := range query.Ids {
+				if _, ok := refunds[id]; ok {
+					body += "<item>" + id + "</item>"
+				}
+			}
+			writeXML(w, http.StatusOK, body+"</ids></search-results>")
+		case "/merchants/merchant-id/transactions/advanced_search":
+			pages = append(pages, query.Ids)
+			body := "<credit-card-transactions>"
+			for _, id := range query.Ids {
+				body += refunds[id]
+			}
+			writeXML(w, http.StatusOK, body+"</credit-card-transactions>")
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	g := testServerGateway(srv).Transaction()
+
+	tx := &Transaction{
+		Id:        "abc123",
+		Type:      TransactionTypeSale,
+		Status:    TransactionStatusSettled,
+		Amount:    NewDecimal(1000, 2),
+		RefundIds: &[]string{"ref1", "ref2", "ref3"},
+	}
+	amount, err := g.RefundableAmount(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if amount.Cmp(NewDecimal(650, 2)) != 0 {
+		t.Fatalf("got %v, want 6.50", amount)
+	}
+	if want := [][]string{{"ref1", "ref2"}, {"ref3"}}; !reflect.DeepEqual(pages, want) {
+		t.Fatalf("got pages %v, want %v", pages, want)
+	}
+
+	tx.RefundIds = nil
+	if amount, err = g.RefundableAmount(ctx, tx); err != nil || amount.Cmp(tx.Amount) != 0 {
+		t.Fatalf("got %v, %v, want the full amount", amount, err)
+	}
+
+	tx.Status = TransactionStatusSubmittedForSettlement
+	if amount := tx.RefundableAmount(nil); amount.Cmp(NewDecimal(0, 2)) != 0 {
+		t.Fatalf("got %v for an unsettled transaction, want 0", amount)
+	}
+
+	tx.Status = TransactionStatusSettled
+	tx.RefundIds = &[]string{"missing"}
+	if _, err := g.RefundableAmount(ctx, tx); err == nil {
+		t.Fatal("expected an error for a refund that cannot be found")
+	}
+}
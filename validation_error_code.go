@@ -0,0 +1,274 @@
+package braintree
+
+// ValidationErrorCode is the code of a validation error returned by the gateway.
+type ValidationErrorCode string
+
+const (
+	ErrorCodeAddressCannotBeBlank                       ValidationErrorCode = "81801"
+	ErrorCodeAddressCompanyIsTooLong                    ValidationErrorCode = "81802"
+	ErrorCodeAddressCountryNameIsNotAccepted            ValidationErrorCode = "91803"
+	ErrorCodeAddressExtendedAddressIsTooLong            ValidationErrorCode = "81804"
+	ErrorCodeAddressFirstNameIsTooLong                  ValidationErrorCode = "81805"
+	ErrorCodeAddressLastNameIsTooLong                   ValidationErrorCode = "81806"
+	ErrorCodeAddressLocalityIsTooLong                   ValidationErrorCode = "81807"
+	ErrorCodeAddressPostalCodeIsRequired                ValidationErrorCode = "81808"
+	ErrorCodeAddressPostalCodeIsTooLong                 ValidationErrorCode = "81809"
+	ErrorCodeAddressRegionIsTooLong                     ValidationErrorCode = "81810"
+	ErrorCodeAddressStreetAddressIsRequired             ValidationErrorCode = "81811"
+	ErrorCodeAddressStreetAddressIsTooLong              ValidationErrorCode = "81812"
+	ErrorCodeAddressPostalCodeInvalidCharacters         ValidationErrorCode = "81813"
+	ErrorCodeAddressInconsistentCountry                 ValidationErrorCode = "91815"
+	ErrorCodeAddressTooManyAddressesPerCustomer         ValidationErrorCode = "91818"
+	ErrorCodeCreditCardBillingAddressConflict           ValidationErrorCode = "91701"
+	ErrorCodeCreditCardBillingAddressIdIsInvalid        ValidationErrorCode = "91702"
+	ErrorCodeCreditCardTypeIsNotAccepted                ValidationErrorCode = "81703"
+	ErrorCodeCreditCardCustomerIdIsRequired             ValidationErrorCode = "91704"
+	ErrorCodeCreditCardCustomerIdIsInvalid              ValidationErrorCode = "91705"
+	ErrorCodeCreditCardCVVIsRequired                    ValidationErrorCode = "81706"
+	ErrorCodeCreditCardCVVIsInvalid                     ValidationErrorCode = "81707"
+	ErrorCodeCreditCardExpirationDateConflict           ValidationErrorCode = "91708"
+	ErrorCodeCreditCardExpirationDateIsRequired         ValidationErrorCode = "81709"
+	ErrorCodeCreditCardExpirationDateIsInvalid          ValidationErrorCode = "81710"
+	ErrorCodeCreditCardExpirationDateYearIsInvalid      ValidationErrorCode = "81711"
+	ErrorCodeCreditCardExpirationMonthIsInvalid         ValidationErrorCode = "81712"
+	ErrorCodeCreditCardExpirationYearIsInvalid          ValidationErrorCode = "81713"
+	ErrorCodeCreditCardNumberIsRequired                 ValidationErrorCode = "81714"
+	ErrorCodeCreditCardNumberIsInvalid                  ValidationErrorCode = "81715"
+	ErrorCodeCreditCardNumberLengthIsInvalid            ValidationErrorCode = "81716"
+	ErrorCodeCreditCardNumberMustBeTestNumber           ValidationErrorCode = "81717"
+	ErrorCodeCreditCardTokenInvalid                     ValidationErrorCode = "91718"
+	ErrorCodeCreditCardTokenIsInUse                     ValidationErrorCode = "91719"
+	ErrorCodeCreditCardTokenIsTooLong                   ValidationErrorCode = "91720"
+	ErrorCodeCreditCardTokenIsNotAllowed                ValidationErrorCode = "91721"
+	ErrorCodeCreditCardTokenIsRequired                  ValidationErrorCode = "91722"
+	ErrorCodeCreditCardCardholderNameIsTooLong          ValidationErrorCode = "81723"
+	ErrorCodeCreditCardDuplicateCardExists              ValidationErrorCode = "81724"
+	ErrorCodeCreditCardPaymentMethodConflict            ValidationErrorCode = "81725"
+	ErrorCodeCreditCardPaymentMethodNonceConsumed       ValidationErrorCode = "91731"
+	ErrorCodeCreditCardPaymentMethodNonceUnknown        ValidationErrorCode = "91732"
+	ErrorCodeCreditCardPaymentMethodNonceLocked         ValidationErrorCode = "91733"
+	ErrorCodeCreditCardCVVVerificationFailed            ValidationErrorCode = "81736"
+	ErrorCodeCreditCardPostalCodeVerificationFailed     ValidationErrorCode = "81737"
+	ErrorCodeCustomerCompanyIsTooLong                   ValidationErrorCode = "81601"
+	ErrorCodeCustomerCustomFieldIsInvalid               ValidationErrorCode = "91602"
+	ErrorCodeCustomerCustomFieldIsTooLong               ValidationErrorCode = "81603"
+	ErrorCodeCustomerEmailIsInvalid                     ValidationErrorCode = "81604"
+	ErrorCodeCustomerEmailIsTooLong                     ValidationErrorCode = "81605"
+	ErrorCodeCustomerEmailIsRequired                    ValidationErrorCode = "81606"
+	ErrorCodeCustomerFaxIsTooLong                       ValidationErrorCode = "81607"
+	ErrorCodeCustomerFirstNameIsTooLong                 ValidationErrorCode = "81608"
+	ErrorCodeCustomerIdIsInUse                          ValidationErrorCode = "91609"
+	ErrorCodeCustomerIdIsInvalid                        ValidationErrorCode = "91610"
+	ErrorCodeCustomerIdIsNotAllowed                     ValidationErrorCode = "91611"
+	ErrorCodeCustomerIdIsTooLong                        ValidationErrorCode = "91612"
+	ErrorCodeCustomerIdIsRequired                       ValidationErrorCode = "91613"
+	ErrorCodeCustomerLastNameIsTooLong                  ValidationErrorCode = "81613"
+	ErrorCodeCustomerPhoneIsTooLong                     ValidationErrorCode = "81614"
+	ErrorCodeCustomerWebsiteIsTooLong                   ValidationErrorCode = "81615"
+	ErrorCodeCustomerWebsiteIsInvalid                   ValidationErrorCode = "81616"
+	ErrorCodeDescriptorNameFormatIsInvalid              ValidationErrorCode = "92201"
+	ErrorCodeDescriptorPhoneFormatIsInvalid             ValidationErrorCode = "92202"
+	ErrorCodeDescriptorDynamicDescriptorsDisabled       ValidationErrorCode = "92203"
+	ErrorCodeDescriptorInternationalNameFormatIsInvalid ValidationErrorCode = "92204"
+	ErrorCodeDescriptorInternationalPhoneFormatInvalid  ValidationErrorCode = "92205"
+	ErrorCodeDescriptorURLFormatIsInvalid               ValidationErrorCode = "92206"
+	ErrorCodeSubscriptionCannotEditCanceled             ValidationErrorCode = "81901"
+	ErrorCodeSubscriptionIdIsInUse                      ValidationErrorCode = "81902"
+	ErrorCodeSubscriptionPriceCannotBeBlank             ValidationErrorCode = "81903"
+	ErrorCodeSubscriptionPriceFormatIsInvalid           ValidationErrorCode = "81904"
+	ErrorCodeSubscriptionStatusIsCanceled               ValidationErrorCode = "81905"
+	ErrorCodeSubscriptionTokenFormatIsInvalid           ValidationErrorCode = "81906"
+	ErrorCodeSubscriptionTrialDurationFormatIsInvalid   ValidationErrorCode = "81907"
+	ErrorCodeSubscriptionTrialDurationIsRequired        ValidationErrorCode = "81908"
+	ErrorCodeSubscriptionTrialDurationUnitIsInvalid     ValidationErrorCode = "81909"
+	ErrorCodeSubscriptionCannotEditExpired              ValidationErrorCode = "81910"
+	ErrorCodeSubscriptionPaymentMethodTokenNotAccepted  ValidationErrorCode = "91902"
+	ErrorCodeSubscriptionPaymentMethodTokenIsInvalid    ValidationErrorCode = "91903"
+	ErrorCodeSubscriptionPlanIdIsInvalid                ValidationErrorCode = "91904"
+	ErrorCodeSubscriptionInconsistentBillingCycles      ValidationErrorCode = "91908"
+	ErrorCodeSubscriptionBillingDayCannotBeUpdated      ValidationErrorCode = "91918"
+	ErrorCodeSubscriptionPriceIsTooLarge                ValidationErrorCode = "81923"
+	ErrorCodeSubscriptionStatusMustBePastDue            ValidationErrorCode = "91531"
+	ErrorCodeTransactionAmountCannotBeNegative          ValidationErrorCode = "81501"
+	ErrorCodeTransactionAmountIsRequired                ValidationErrorCode = "81502"
+	ErrorCodeTransactionAmountIsInvalid                 ValidationErrorCode = "81503"
+	ErrorCodeTransactionAmountIsTooLarge                ValidationErrorCode = "81528"
+	ErrorCodeTransactionAmountMustBeGreaterThanZero     ValidationErrorCode = "81531"
+	ErrorCodeTransactionOrderIdIsTooLong                ValidationErrorCode = "91501"
+	ErrorCodeTransactionCannotBeVoided                  ValidationErrorCode = "91504"
+	ErrorCodeTransactionCannotRefundCredit              ValidationErrorCode = "91505"
+	ErrorCodeTransactionCannotRefundUnlessSettled       ValidationErrorCode = "91506"
+	ErrorCodeTransactionCannotSubmitForSettlement       ValidationErrorCode = "91507"
+	ErrorCodeTransactionCreditCardIsRequired            ValidationErrorCode = "91508"
+	ErrorCodeTransactionCustomerCardTypeIsNotAccepted   ValidationErrorCode = "81509"
+	ErrorCodeTransactionCustomerIdIsInvalid             ValidationErrorCode = "91510"
+	ErrorCodeTransactionCustomerDoesNotHaveCreditCard   ValidationErrorCode = "91511"
+	ErrorCodeTransactionHasAlreadyBeenRefunded          ValidationErrorCode = "91512"
+	ErrorCodeTransactionMerchantAccountIdIsInvalid      ValidationErrorCode = "91513"
+	ErrorCodeTransactionMerchantAccountIsSuspended      ValidationErrorCode = "91514"
+	ErrorCodeTransactionPaymentMethodConflict           ValidationErrorCode = "91515"
+	ErrorCodeTransactionPaymentMethodNotOwnedByCustomer ValidationErrorCode = "91516"
+	ErrorCodeTransactionPaymentMethodTokenNotAccepted   ValidationErrorCode = "91517"
+	ErrorCodeTransactionPaymentMethodTokenIsInvalid     ValidationErrorCode = "91518"
+	ErrorCodeTransactionRefundAmountIsTooLarge          ValidationErrorCode = "91521"
+	ErrorCodeTransactionSettlementAmountIsTooLarge      ValidationErrorCode = "91522"
+	ErrorCodeTransactionTypeIsInvalid                   ValidationErrorCode = "91523"
+	ErrorCodeTransactionTypeIsRequired                  ValidationErrorCode = "91524"
+	ErrorCodeTransactionCustomFieldIsInvalid            ValidationErrorCode = "91526"
+	ErrorCodeTransactionCustomFieldIsTooLong            ValidationErrorCode = "81527"
+	ErrorCodeTransactionSubscriptionIdIsInvalid         ValidationErrorCode = "91528"
+	ErrorCodeTransactionTaxAmountCannotBeNegative       ValidationErrorCode = "81534"
+	ErrorCodeTransactionTaxAmountFormatIsInvalid        ValidationErrorCode = "81535"
+	ErrorCodeTransactionTaxAmountIsTooLarge             ValidationErrorCode = "81536"
+	ErrorCodeTransactionPurchaseOrderNumberIsTooLong    ValidationErrorCode = "91537"
+	ErrorCodeTransactionPurchaseOrderNumberIsInvalid    ValidationErrorCode = "91548"
+	ErrorCodeTransactionServiceFeeAmountIsTooLarge      ValidationErrorCode = "91556"
+	ErrorCodeTransactionServiceFeeNotAllowedOnMaster    ValidationErrorCode = "91557"
+	ErrorCodeTransactionCannotHoldInEscrow              ValidationErrorCode = "91560"
+	ErrorCodeTransactionCannotReleaseFromEscrow         ValidationErrorCode = "91561"
+	ErrorCodeTransactionCannotCancelRelease             ValidationErrorCode = "91562"
+	ErrorCodeTransactionPaymentMethodNonceUnknown       ValidationErrorCode = "91565"
+)
+
+var validationErrors = map[ValidationErrorCode]responseCodeInfo{
+	ErrorCodeAddressCannotBeBlank:                       {ResponseCategoryValidation, "Address must have at least one field filled in."},
+	ErrorCodeAddressCompanyIsTooLong:                    {ResponseCategoryValidation, "Company is too long."},
+	ErrorCodeAddressCountryNameIsNotAccepted:            {ResponseCategoryValidation, "Country name is not an accepted country."},
+	ErrorCodeAddressExtendedAddressIsTooLong:            {ResponseCategoryValidation, "Extended address is too long."},
+	ErrorCodeAddressFirstNameIsTooLong:                  {ResponseCategoryValidation, "First name is too long."},
+	ErrorCodeAddressLastNameIsTooLong:                   {ResponseCategoryValidation, "Last name is too long."},
+	ErrorCodeAddressLocalityIsTooLong:                   {ResponseCategoryValidation, "Locality is too long."},
+	ErrorCodeAddressPostalCodeIsRequired:                {ResponseCategoryValidation, "Postal code is required."},
+	ErrorCodeAddressPostalCodeIsTooLong:                 {ResponseCategoryValidation, "Postal code is too long."},
+	ErrorCodeAddressRegionIsTooLong:                     {ResponseCategoryValidation, "Region is too long."},
+	ErrorCodeAddressStreetAddressIsRequired:             {ResponseCategoryValidation, "Street address is required."},
+	ErrorCodeAddressStreetAddressIsTooLong:              {ResponseCategoryValidation, "Street address is too long."},
+	ErrorCodeAddressPostalCodeInvalidCharacters:         {ResponseCategoryValidation, "Postal code can only contain letters, numbers, spaces, and hyphens."},
+	ErrorCodeAddressInconsistentCountry:                 {ResponseCategoryValidation, "Inconsistent country."},
+	ErrorCodeAddressTooManyAddressesPerCustomer:         {ResponseCategoryValidation, "Customer has already reached the maximum of 50 addresses."},
+	ErrorCodeCreditCardBillingAddressConflict:           {ResponseCategoryValidation, "Billing address and billing address ID cannot both be provided."},
+	ErrorCodeCreditCardBillingAddressIdIsInvalid:        {ResponseCategoryValidation, "Billing address ID is invalid."},
+	ErrorCodeCreditCardTypeIsNotAccepted:                {ResponseCategoryValidation, "Credit card type is not accepted by this merchant account."},
+	ErrorCodeCreditCardCustomerIdIsRequired:             {ResponseCategoryValidation, "Customer ID is required."},
+	ErrorCodeCreditCardCustomerIdIsInvalid:              {ResponseCategoryValidation, "Customer ID is invalid."},
+	ErrorCodeCreditCardCVVIsRequired:                    {ResponseCategoryValidation, "CVV is required."},
+	ErrorCodeCreditCardCVVIsInvalid:                     {ResponseCategoryValidation, "CVV must be 4 digits for American Express and 3 digits for other card types."},
+	ErrorCodeCreditCardExpirationDateConflict:           {ResponseCategoryValidation, "Cannot provide expiration_date if you are also providing expiration_month and expiration_year."},
+	ErrorCodeCreditCardExpirationDateIsRequired:         {ResponseCategoryValidation, "Expiration date is required."},
+	ErrorCodeCreditCardExpirationDateIsInvalid:          {ResponseCategoryValidation, "Expiration date is invalid."},
+	ErrorCodeCreditCardExpirationDateYearIsInvalid:      {ResponseCategoryValidation, "Expiration year is invalid."},
+	ErrorCodeCreditCardExpirationMonthIsInvalid:         {ResponseCategoryValidation, "Expiration month is invalid."},
+	ErrorCodeCreditCardExpirationYearIsInvalid:          {ResponseCategoryValidation, "Expiration year is invalid."},
+	ErrorCodeCreditCardNumberIsRequired:                 {ResponseCategoryValidation, "Credit card number is required."},
+	ErrorCodeCreditCardNumberIsInvalid:                  {ResponseCategoryValidation, "Credit card number is invalid."},
+	ErrorCodeCreditCardNumberLengthIsInvalid:            {ResponseCategoryValidation, "Credit card number must be 12-19 digits."},
+	ErrorCodeCreditCardNumberMustBeTestNumber:           {ResponseCategoryValidation, "Credit card number must be a test number in the sandbox."},
+	ErrorCodeCreditCardTokenInvalid:                     {ResponseCategoryValidation, "Token is invalid."},
+	ErrorCodeCreditCardTokenIsInUse:                     {ResponseCategoryValidation, "Token is already in use."},
+	ErrorCodeCreditCardTokenIsTooLong:                   {ResponseCategoryValidation, "Token is too long."},
+	ErrorCodeCreditCardTokenIsNotAllowed:                {ResponseCategoryValidation, "Token is not allowed."},
+	ErrorCodeCreditCardTokenIsRequired:                  {ResponseCategoryValidation, "Token is required."},
+	ErrorCodeCreditCardCardholderNameIsTooLong:          {ResponseCategoryValidation, "Cardholder name is too long."},
+	ErrorCodeCreditCardDuplicateCardExists:              {ResponseCategoryValidation, "Duplicate card exists in the vault."},
+	ErrorCodeCreditCardPaymentMethodConflict:            {ResponseCategoryValidation, "Credit card must include number, payment_method_nonce, or venmo_sdk_payment_method_code."},
+	ErrorCodeCreditCardPaymentMethodNonceConsumed:       {ResponseCategoryValidation, "Payment method nonce has already been consumed."},
+	ErrorCodeCreditCardPaymentMethodNonceUnknown:        {ResponseCategoryValidation, "Unknown payment method nonce."},
+	ErrorCodeCreditCardPaymentMethodNonceLocked:         {ResponseCategoryValidation, "Payment method nonce is locked."},
+	ErrorCodeCreditCardCVVVerificationFailed:            {ResponseCategoryFraud, "CVV verification failed."},
+	ErrorCodeCreditCardPostalCodeVerificationFailed:     {ResponseCategoryFraud, "Postal code verification failed."},
+	ErrorCodeCustomerCompanyIsTooLong:                   {ResponseCategoryValidation, "Company is too long."},
+	ErrorCodeCustomerCustomFieldIsInvalid:               {ResponseCategoryValidation, "Custom field is invalid."},
+	ErrorCodeCustomerCustomFieldIsTooLong:               {ResponseCategoryValidation, "Custom field is too long."},
+	ErrorCodeCustomerEmailIsInvalid:                     {ResponseCategoryValidation, "Email is an invalid format."},
+	ErrorCodeCustomerEmailIsTooLong:                     {ResponseCategoryValidation, "Email is too long."},
+	ErrorCodeCustomerEmailIsRequired:                    {ResponseCategoryValidation, "Email is required."},
+	ErrorCodeCustomerFaxIsTooLong:                       {ResponseCategoryValidation, "Fax is too long."},
+	ErrorCodeCustomerFirstNameIsTooLong:                 {ResponseCategoryValidation, "First name is too long."},
+	ErrorCodeCustomerIdIsInUse:                          {ResponseCategoryValidation, "Customer ID has already been taken."},
+	ErrorCodeCustomerIdIsInvalid:                        {ResponseCategoryValidation, "Customer ID is invalid."},
+	ErrorCodeCustomerIdIsNotAllowed:                     {ResponseCategoryValidation, "Customer ID is not allowed."},
+	ErrorCodeCustomerIdIsTooLong:                        {ResponseCategoryValidation, "Customer ID is too long."},
+	ErrorCodeCustomerIdIsRequired:                       {ResponseCategoryValidation, "Customer ID is required."},
+	ErrorCodeCustomerLastNameIsTooLong:                  {ResponseCategoryValidation, "Last name is too long."},
+	ErrorCodeCustomerPhoneIsTooLong:                     {ResponseCategoryValidation, "Phone is too long."},
+	ErrorCodeCustomerWebsiteIsTooLong:                   {ResponseCategoryValidation, "Website is too long."},
+	ErrorCodeCustomerWebsiteIsInvalid:                   {ResponseCategoryValidation, "Website is an invalid format."},
+	ErrorCodeDescriptorNameFormatIsInvalid:              {ResponseCategoryValidation, "Descriptor name format is invalid."},
+	ErrorCodeDescriptorPhoneFormatIsInvalid:             {ResponseCategoryValidation, "Descriptor phone format is invalid."},
+	ErrorCodeDescriptorDynamicDescriptorsDisabled:       {ResponseCategoryValidation, "Dynamic descriptors have not been enabled for this account."},
+	ErrorCodeDescriptorInternationalNameFormatIsInvalid: {ResponseCategoryValidation, "International descriptor name format is invalid."},
+	ErrorCodeDescriptorInternationalPhoneFormatInvalid:  {ResponseCategoryValidation, "International descriptor phone format is invalid."},
+	ErrorCodeDescriptorURLFormatIsInvalid:               {ResponseCategoryValidation, "Descriptor URL format is invalid."},
+	ErrorCodeSubscriptionCannotEditCanceled:             {ResponseCategoryValidation, "Subscription has been canceled and cannot be edited."},
+	ErrorCodeSubscriptionIdIsInUse:                      {ResponseCategoryValidation, "Subscription ID has already been taken."},
+	ErrorCodeSubscriptionPriceCannotBeBlank:             {ResponseCategoryValidation, "Price cannot be blank."},
+	ErrorCodeSubscriptionPriceFormatIsInvalid:           {ResponseCategoryValidation, "Price is an invalid format."},
+	ErrorCodeSubscriptionStatusIsCanceled:               {ResponseCategoryValidation, "Subscription has already been canceled."},
+	ErrorCodeSubscriptionTokenFormatIsInvalid:           {ResponseCategoryValidation, "Subscription ID is an invalid format."},
+	ErrorCodeSubscriptionTrialDurationFormatIsInvalid:   {ResponseCategoryValidation, "Trial duration is an invalid format."},
+	ErrorCodeSubscriptionTrialDurationIsRequired:        {ResponseCategoryValidation, "Trial duration is required."},
+	ErrorCodeSubscriptionTrialDurationUnitIsInvalid:     {ResponseCategoryValidation, "Trial duration unit is invalid."},
+	ErrorCodeSubscriptionCannotEditExpired:              {ResponseCategoryValidation, "Subscription has expired and cannot be edited."},
+	ErrorCodeSubscriptionPaymentMethodTokenNotAccepted:  {ResponseCategoryValidation, "Payment method token card type is not accepted by this merchant account."},
+	ErrorCodeSubscriptionPaymentMethodTokenIsInvalid:    {ResponseCategoryValidation, "Payment method token is invalid."},
+	ErrorCodeSubscriptionPlanIdIsInvalid:                {ResponseCategoryValidation, "Plan ID is invalid."},
+	ErrorCodeSubscriptionInconsistentBillingCycles:      {ResponseCategoryValidation, "Number of billing cycles cannot be set with never expires."},
+	ErrorCodeSubscriptionBillingDayCannotBeUpdated:      {ResponseCategoryValidation, "Billing day of month cannot be updated."},
+	ErrorCodeSubscriptionPriceIsTooLarge:                {ResponseCategoryValidation, "Price is too large."},
+	ErrorCodeSubscriptionStatusMustBePastDue:            {ResponseCategoryValidation, "Subscription status must be Past Due in order to retry."},
+	ErrorCodeTransactionAmountCannotBeNegative:          {ResponseCategoryValidation, "Amount cannot be negative."},
+	ErrorCodeTransactionAmountIsRequired:                {ResponseCategoryValidation, "Amount is required."},
+	ErrorCodeTransactionAmountIsInvalid:                 {ResponseCategoryValidation, "Amount is an invalid format."},
+	ErrorCodeTransactionAmountIsTooLarge:                {ResponseCategoryValidation, "Amount is too large."},
+	ErrorCodeTransactionAmountMustBeGreaterThanZero:     {ResponseCategoryValidation, "Amount must be greater than zero."},
+	ErrorCodeTransactionOrderIdIsTooLong:                {ResponseCategoryValidation, "Order ID is too long."},
+	ErrorCodeTransactionCannotBeVoided:                  {ResponseCategoryValidation, "Transaction can only be voided if status is authorized or submitted_for_settlement."},
+	ErrorCodeTransactionCannotRefundCredit:              {ResponseCategoryValidation, "Cannot refund a credit."},
+	ErrorCodeTransactionCannotRefundUnlessSettled:       {ResponseCategoryValidation, "Cannot refund a transaction unless it is settled."},
+	ErrorCodeTransactionCannotSubmitForSettlement:       {ResponseCategoryValidation, "Cannot submit for settlement unless status is authorized."},
+	ErrorCodeTransactionCreditCardIsRequired:            {ResponseCategoryValidation, "Credit card is required."},
+	ErrorCodeTransactionCustomerCardTypeIsNotAccepted:   {ResponseCategoryValidation, "Customer's default payment method card type is not accepted by this merchant account."},
+	ErrorCodeTransactionCustomerIdIsInvalid:             {ResponseCategoryValidation, "Customer ID is invalid."},
+	ErrorCodeTransactionCustomerDoesNotHaveCreditCard:   {ResponseCategoryValidation, "Customer does not have any credit cards."},
+	ErrorCodeTransactionHasAlreadyBeenRefunded:          {ResponseCategoryValidation, "Transaction has already been completely refunded."},
+	ErrorCodeTransactionMerchantAccountIdIsInvalid:      {ResponseCategoryValidation, "Merchant account ID is invalid."},
+	ErrorCodeTransactionMerchantAccountIsSuspended:      {ResponseCategoryValidation, "Merchant account is suspended."},
+	ErrorCodeTransactionPaymentMethodConflict:           {ResponseCategoryValidation, "Cannot provide both payment_method_token and credit card attributes."},
+	ErrorCodeTransactionPaymentMethodNotOwnedByCustomer: {ResponseCategoryValidation, "Payment method does not belong to this customer."},
+	ErrorCodeTransactionPaymentMethodTokenNotAccepted:   {ResponseCategoryValidation, "Payment method token card type is not accepted by this merchant account."},
+	ErrorCodeTransactionPaymentMethodTokenIsInvalid:     {ResponseCategoryValidation, "Payment method token is invalid."},
+	ErrorCodeTransactionRefundAmountIsTooLarge:          {ResponseCategoryValidation, "Refund amount is too large."},
+	ErrorCodeTransactionSettlementAmountIsTooLarge:      {ResponseCategoryValidation, "Settlement amount cannot be more than the authorized amount."},
+	ErrorCodeTransactionTypeIsInvalid:                   {ResponseCategoryValidation, "Transaction type is invalid."},
+	ErrorCodeTransactionTypeIsRequired:                  {ResponseCategoryValidation, "Transaction type is required."},
+	ErrorCodeTransactionCustomFieldIsInvalid:            {ResponseCategoryValidation, "Custom field is invalid."},
+	ErrorCodeTransactionCustomFieldIsTooLong:            {ResponseCategoryValidation, "Custom field is too long."},
+	ErrorCodeTransactionSubscriptionIdIsInvalid:         {ResponseCategoryValidation, "Subscription ID is invalid."},
+	ErrorCodeTransactionTaxAmountCannotBeNegative:       {ResponseCategoryValidation, "Tax amount cannot be negative."},
+	ErrorCodeTransactionTaxAmountFormatIsInvalid:        {ResponseCategoryValidation, "Tax amount is an invalid format."},
+	ErrorCodeTransactionTaxAmountIsTooLarge:             {ResponseCategoryValidation, "Tax amount is too large."},
+	ErrorCodeTransactionPurchaseOrderNumberIsTooLong:    {ResponseCategoryValidation, "Purchase order number is too long."},
+	ErrorCodeTransactionPurchaseOrderNumberIsInvalid:    {ResponseCategoryValidation, "Purchase order number is invalid."},
+	ErrorCodeTransactionServiceFeeAmountIsTooLarge:      {ResponseCategoryValidation, "Service fee amount is too large."},
+	ErrorCodeTransactionServiceFeeNotAllowedOnMaster:    {ResponseCategoryValidation, "Service fee amount is not allowed on master merchant account."},
+	ErrorCodeTransactionCannotHoldInEscrow:              {ResponseCategoryValidation, "Transaction could not be held in escrow, only transactions on sub-merchant accounts can be."},
+	ErrorCodeTransactionCannotReleaseFromEscrow:         {ResponseCategoryValidation, "Cannot release a transaction that is not held in escrow."},
+	ErrorCodeTransactionCannotCancelRelease:             {ResponseCategoryValidation, "Release can only be cancelled if the transaction is pending release."},
+	ErrorCodeTransactionPaymentMethodNonceUnknown:       {ResponseCategoryValidation, "Unknown payment method nonce."},
+}
+
+// Category returns the category of the code. All validation errors are in
+// ResponseCategoryValidation, except failed card verifications, which are in
+// ResponseCategoryFraud.
+func (c ValidationErrorCode) Category() ResponseCategory {
+	if info, ok := validationErrors[c]; ok {
+		return info.category
+	}
+	return ResponseCategoryValidation
+}
+
+// Message returns the default human readable message for the code, or "" if the code
+// is not in the catalog.
+func (c ValidationErrorCode) Message() string {
+	return validationErrors[c].message
+}